@@ -0,0 +1,7 @@
+package config
+
+import "os"
+
+func GetExchangeRateFile() string {
+	return os.Getenv("EXCHANGE_RATE_FILE")
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+func GetAppBaseURL() string {
+	baseURL := os.Getenv("APP_BASE_URL")
+
+	if baseURL == "" {
+		return "http://localhost:8080"
+	}
+
+	return baseURL
+}
+
+// GetGuestOrderLinkSecret returns the key that signs guest order links, JWT_SECRET_KEY when it is not set.
+func GetGuestOrderLinkSecret() []byte {
+	secret := os.Getenv("GUEST_ORDER_LINK_SECRET")
+
+	if secret == "" {
+		return GetJwtSecret()
+	}
+
+	return []byte(secret)
+}
+
+func GetGuestOrderLinkTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("GUEST_ORDER_LINK_TTL"))
+
+	if err != nil || ttl <= 0 {
+		return 30 * 24 * time.Hour
+	}
+
+	return ttl
+}
+
+func GetGuestClaimLinkTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("GUEST_CLAIM_LINK_TTL"))
+
+	if err != nil || ttl <= 0 {
+		return 24 * time.Hour
+	}
+
+	return ttl
+}
@@ -0,0 +1,37 @@
+package config
+
+import "os"
+
+type SellerDetails struct {
+	Nama    string
+	Alamat  string
+	Telepon string
+	Email   string
+	NPWP    string
+}
+
+func GetInvoiceSeller() SellerDetails {
+	seller := SellerDetails{
+		Nama:    os.Getenv("INVOICE_SELLER_NAME"),
+		Alamat:  os.Getenv("INVOICE_SELLER_ADDRESS"),
+		Telepon: os.Getenv("INVOICE_SELLER_PHONE"),
+		Email:   os.Getenv("INVOICE_SELLER_EMAIL"),
+		NPWP:    os.Getenv("INVOICE_SELLER_NPWP"),
+	}
+
+	if seller.Nama == "" {
+		seller.Nama = "Golang Order Product"
+	}
+
+	return seller
+}
+
+func GetInvoicePrefix() string {
+	prefix := os.Getenv("INVOICE_PREFIX")
+
+	if prefix == "" {
+		return "INV"
+	}
+
+	return prefix
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+func GetEmailSender() string {
+	sender := os.Getenv("EMAIL_SENDER")
+
+	if sender == "" {
+		return "log"
+	}
+
+	return sender
+}
+
+func GetEmailFrom() string {
+	from := os.Getenv("EMAIL_FROM")
+
+	if from == "" {
+		return "no-reply@localhost"
+	}
+
+	return from
+}
+
+func GetEmailFileDir() string {
+	dir := os.Getenv("EMAIL_FILE_DIR")
+
+	if dir == "" {
+		return "mail"
+	}
+
+	return dir
+}
+
+type SMTPSettings struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+}
+
+func GetSMTPSettings() SMTPSettings {
+	settings := SMTPSettings{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+	}
+
+	if settings.Port == "" {
+		settings.Port = "587"
+	}
+
+	return settings
+}
+
+func GetNotificationDefaultLanguage() string {
+	if os.Getenv("NOTIFICATION_DEFAULT_LANGUAGE") == "en" {
+		return "en"
+	}
+
+	return "id"
+}
+
+func GetNotificationSendInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("NOTIFICATION_SEND_INTERVAL"))
+
+	if err != nil || interval < 0 {
+		return time.Minute
+	}
+
+	return interval
+}
+
+func GetNotificationMaxAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("NOTIFICATION_MAX_ATTEMPTS"))
+
+	if err != nil || attempts <= 0 {
+		return 5
+	}
+
+	return attempts
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+func GetOrderNumberFormat() string {
+	format := os.Getenv("ORDER_NUMBER_FORMAT")
+
+	if format == "" {
+		return "ORD-{YYYY}-{MM}-{SEQ:6}"
+	}
+
+	return format
+}
+
+func GetOrderDeletedRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("ORDER_DELETED_RETENTION_DAYS"))
+
+	if err != nil || days < 0 {
+		return 30 * 24 * time.Hour
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func GetOrderArchiveAfter() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("ORDER_ARCHIVE_AFTER_DAYS"))
+
+	if err != nil || days <= 0 {
+		return 365 * 24 * time.Hour
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func GetOrderMaintenanceInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("ORDER_MAINTENANCE_INTERVAL"))
+
+	if err != nil || interval < 0 {
+		return 24 * time.Hour
+	}
+
+	return interval
+}
+
+func GetOrderPaymentTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("ORDER_PAYMENT_TIMEOUT"))
+
+	if err != nil || timeout < 0 {
+		return 24 * time.Hour
+	}
+
+	return timeout
+}
+
+func GetOrderAutoCancelInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("ORDER_AUTO_CANCEL_INTERVAL"))
+
+	if err != nil || interval <= 0 {
+		return 5 * time.Minute
+	}
+
+	return interval
+}
+
+// GetOrderImportMaxSize returns the largest order import upload in bytes.
+func GetOrderImportMaxSize() int64 {
+	megabytes, err := strconv.Atoi(os.Getenv("ORDER_IMPORT_MAX_SIZE_MB"))
+
+	if err != nil || megabytes <= 0 {
+		return 10 << 20
+	}
+
+	return int64(megabytes) << 20
+}
+
+func GetOrderImportMaxRows() int {
+	rows, err := strconv.Atoi(os.Getenv("ORDER_IMPORT_MAX_ROWS"))
+
+	if err != nil || rows <= 0 {
+		return 10000
+	}
+
+	return rows
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+func GetPaymentWebhookSecret() []byte {
+	return []byte(os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+}
+
+func IsMockPaymentEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("PAYMENT_MOCK_ENABLED"))
+
+	if err != nil {
+		return false
+	}
+
+	return enabled
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"golang-api/models"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type WeightRate struct {
+	MaxBerat int
+	Ongkir   models.Money
+}
+
+func GetShippingDefaultMethod() string {
+	method := os.Getenv("SHIPPING_DEFAULT_METHOD")
+
+	if method == "" {
+		return "flat"
+	}
+
+	return method
+}
+
+func GetShippingFlatRate() models.Money {
+	rate, err := models.ParseMoney(os.Getenv("SHIPPING_FLAT_RATE"))
+
+	if err != nil || rate < 0 {
+		return models.NewMoneyFromFloat(15000)
+	}
+
+	return rate
+}
+
+func GetShippingWeightRates() []WeightRate {
+	var rates []WeightRate
+
+	for _, pair := range strings.Split(os.Getenv("SHIPPING_WEIGHT_RATES"), ",") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		maxBerat, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil || maxBerat <= 0 {
+			continue
+		}
+
+		ongkir, err := models.ParseMoney(parts[1])
+		if err != nil || ongkir < 0 {
+			continue
+		}
+
+		rates = append(rates, WeightRate{MaxBerat: maxBerat, Ongkir: ongkir})
+	}
+
+	if len(rates) == 0 {
+		rates = []WeightRate{
+			{MaxBerat: 1000, Ongkir: models.NewMoneyFromFloat(10000)},
+			{MaxBerat: 5000, Ongkir: models.NewMoneyFromFloat(25000)},
+			{MaxBerat: 20000, Ongkir: models.NewMoneyFromFloat(60000)},
+		}
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].MaxBerat < rates[j].MaxBerat
+	})
+
+	return rates
+}
+
+func GetShippingExtraPerKg() models.Money {
+	rate, err := models.ParseMoney(os.Getenv("SHIPPING_EXTRA_PER_KG"))
+
+	if err != nil || rate < 0 {
+		return models.NewMoneyFromFloat(5000)
+	}
+
+	return rate
+}
+
+func GetShippingFreeThreshold() models.Money {
+	threshold, err := models.ParseMoney(os.Getenv("SHIPPING_FREE_THRESHOLD"))
+
+	if err != nil || threshold < 0 {
+		return 0
+	}
+
+	return threshold
+}
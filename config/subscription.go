@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+func GetSubscriptionRunInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("SUBSCRIPTION_RUN_INTERVAL"))
+
+	if err != nil || interval < 0 {
+		return time.Hour
+	}
+
+	return interval
+}
+
+func GetSubscriptionMaxFailures() int {
+	failures, err := strconv.Atoi(os.Getenv("SUBSCRIPTION_MAX_FAILURES"))
+
+	if err != nil || failures <= 0 {
+		return 3
+	}
+
+	return failures
+}
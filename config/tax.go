@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+func GetTaxDefaultRate() float64 {
+	rate, err := strconv.ParseFloat(os.Getenv("TAX_DEFAULT_RATE"), 64)
+
+	if err != nil || rate < 0 {
+		return 11
+	}
+
+	return rate
+}
+
+func GetTaxCategoryRates() map[string]float64 {
+	rates := make(map[string]float64)
+
+	for _, pair := range strings.Split(os.Getenv("TAX_CATEGORY_RATES"), ",") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || rate < 0 {
+			continue
+		}
+
+		rates[strings.ToLower(strings.TrimSpace(parts[0]))] = rate
+	}
+
+	return rates
+}
+
+func GetTaxRate(kategori string) float64 {
+	if rate, ok := GetTaxCategoryRates()[strings.ToLower(kategori)]; ok {
+		return rate
+	}
+
+	return GetTaxDefaultRate()
+}
+
+func IsTaxInclusive() bool {
+	inclusive, err := strconv.ParseBool(os.Getenv("TAX_PRICES_INCLUDE_TAX"))
+
+	if err != nil {
+		return false
+	}
+
+	return inclusive
+}
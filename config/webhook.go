@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+func GetWebhookDeliveryInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("WEBHOOK_DELIVERY_INTERVAL"))
+
+	if err != nil || interval < 0 {
+		return time.Minute
+	}
+
+	return interval
+}
+
+func GetWebhookTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("WEBHOOK_TIMEOUT"))
+
+	if err != nil || timeout <= 0 {
+		return 10 * time.Second
+	}
+
+	return timeout
+}
+
+func GetWebhookRetryBackoff() time.Duration {
+	backoff, err := time.ParseDuration(os.Getenv("WEBHOOK_RETRY_BACKOFF"))
+
+	if err != nil || backoff <= 0 {
+		return time.Minute
+	}
+
+	return backoff
+}
+
+func GetWebhookMaxAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+
+	if err != nil || attempts <= 0 {
+		return 8
+	}
+
+	return attempts
+}
+
+func GetWebhookMaxFailures() int {
+	failures, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_FAILURES"))
+
+	if err != nil || failures <= 0 {
+		return 20
+	}
+
+	return failures
+}
+
+// AllowWebhookPrivateTargets lets webhooks reach loopback and private network addresses, e.g. a
+// receiver on localhost during development.
+func AllowWebhookPrivateTargets() bool {
+	allowed, err := strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE_TARGETS"))
+
+	if err != nil {
+		return false
+	}
+
+	return allowed
+}
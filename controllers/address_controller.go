@@ -0,0 +1,294 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AddressController struct {
+	AddressService *services.AddressService
+}
+
+func NewAddressController(db *gorm.DB) *AddressController {
+	return &AddressController{
+		AddressService: services.NewAddressService(db),
+	}
+}
+
+func (ac *AddressController) CreateAddress(c *gin.Context) {
+	var req models.AddAddressRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	newAddress := models.Address{
+		UserID:       userID.(uint),
+		Label:        req.Label,
+		NamaPenerima: req.NamaPenerima,
+		Telepon:      req.Telepon,
+		Alamat:       req.Alamat,
+		Kota:         req.Kota,
+		Provinsi:     req.Provinsi,
+		KodePos:      req.KodePos,
+		IsDefault:    req.IsDefault,
+	}
+
+	address, err := ac.AddressService.CreateAddress(&newAddress)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Address successfully created",
+		Data:    ac.convertToAddressResponse(address),
+	})
+}
+
+func (ac *AddressController) GetAddresses(c *gin.Context) {
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	addresses, err := ac.AddressService.GetAddresses(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.AddressResponse
+	for _, address := range addresses {
+		responses = append(responses, ac.convertToAddressResponse(&address))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Addresses successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (ac *AddressController) GetAddressByID(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	address, err := ac.AddressService.GetAddressByIDAndUserID(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Address successfully found",
+		Data:    ac.convertToAddressResponse(address),
+	})
+}
+
+func (ac *AddressController) UpdateAddress(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.UpdateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	address, err := ac.AddressService.GetAddressByIDAndUserID(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if req.Label != "" {
+		address.Label = req.Label
+	}
+	if req.NamaPenerima != "" {
+		address.NamaPenerima = req.NamaPenerima
+	}
+	if req.Telepon != "" {
+		address.Telepon = req.Telepon
+	}
+	if req.Alamat != "" {
+		address.Alamat = req.Alamat
+	}
+	if req.Kota != "" {
+		address.Kota = req.Kota
+	}
+	if req.Provinsi != "" {
+		address.Provinsi = req.Provinsi
+	}
+	if req.KodePos != "" {
+		address.KodePos = req.KodePos
+	}
+	if req.IsDefault {
+		address.IsDefault = true
+	}
+
+	updatedAddress, err := ac.AddressService.UpdateAddress(address)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Address successfully updated",
+		Data:    ac.convertToAddressResponse(updatedAddress),
+	})
+}
+
+func (ac *AddressController) DeleteAddress(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	err = ac.AddressService.DeleteAddress(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Address successfully deleted",
+	})
+}
+
+func (ac *AddressController) convertToAddressResponse(address *models.Address) models.AddressResponse {
+	return models.AddressResponse{
+		ID:           address.ID,
+		UserID:       address.UserID,
+		Label:        address.Label,
+		NamaPenerima: address.NamaPenerima,
+		Telepon:      address.Telepon,
+		Alamat:       address.Alamat,
+		Kota:         address.Kota,
+		Provinsi:     address.Provinsi,
+		KodePos:      address.KodePos,
+		IsDefault:    address.IsDefault,
+		CreatedAt:    address.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    address.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
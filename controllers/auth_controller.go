@@ -32,7 +32,11 @@ func (ac *AuthController) Register(c *gin.Context) {
 
 	token, err := ac.AuthService.Register(&user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+		status := http.StatusInternalServerError
+		if err.Error() == "email was used for a guest checkout, a link to claim it was sent to the email" {
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.APIResponse{
 			Success: false,
 			Message: err.Error(),
 		})
@@ -85,3 +89,45 @@ func (ac *AuthController) Login(c *gin.Context) {
 	})
 
 }
+
+func (ac *AuthController) ClaimGuest(c *gin.Context) {
+	var link models.GuestClaimLinkRequest
+	if err := c.ShouldBindQuery(&link); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	var req models.GuestClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	token, err := ac.AuthService.ClaimGuest(&link, &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "invalid claim link", "claim link has expired":
+			status = http.StatusForbidden
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Guest claimed successfully",
+		Data: map[string]interface{}{
+			"token": token,
+		},
+	})
+}
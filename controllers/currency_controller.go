@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CurrencyController struct {
+	CurrencyService *services.CurrencyService
+}
+
+func NewCurrencyController(db *gorm.DB) *CurrencyController {
+	return &CurrencyController{
+		CurrencyService: services.NewCurrencyService(db),
+	}
+}
+
+func (cc *CurrencyController) GetExchangeRates(c *gin.Context) {
+	rates, err := cc.CurrencyService.GetExchangeRates()
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Exchange rates successfully retrieved",
+		Data:    cc.convertToExchangeRateResponses(rates),
+	})
+}
+
+func (cc *CurrencyController) SetExchangeRate(c *gin.Context) {
+	var req models.UpdateExchangeRateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	rate, err := cc.CurrencyService.SetExchangeRate(c.Param("currency"), req.Kurs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Exchange rate successfully updated",
+		Data:    cc.convertToExchangeRateResponses([]models.ExchangeRate{*rate})[0],
+	})
+}
+
+func (cc *CurrencyController) ImportExchangeRates(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "CSV file is required",
+		})
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "error opening file",
+		})
+		return
+	}
+	defer src.Close()
+
+	rates, err := cc.CurrencyService.ImportExchangeRates(src)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Exchange rates successfully imported",
+		Data:    cc.convertToExchangeRateResponses(rates),
+	})
+}
+
+func (cc *CurrencyController) DeleteExchangeRate(c *gin.Context) {
+	if err := cc.CurrencyService.DeleteExchangeRate(c.Param("currency")); err != nil {
+		status := http.StatusBadRequest
+		switch err.Error() {
+		case "exchange rate not found":
+			status = http.StatusNotFound
+		case "exchange rate is still used by products", "exchange rate is still used by open orders":
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Exchange rate successfully deleted",
+	})
+}
+
+func (cc *CurrencyController) convertToExchangeRateResponses(rates []models.ExchangeRate) []models.ExchangeRateResponse {
+	var responses []models.ExchangeRateResponse
+
+	for _, rate := range rates {
+		responses = append(responses, models.ExchangeRateResponse{
+			ID:        rate.ID,
+			MataUang:  rate.MataUang,
+			Kurs:      rate.Kurs,
+			CreatedAt: rate.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: rate.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return responses
+}
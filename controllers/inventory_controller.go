@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"golang-api/models"
 	"golang-api/services"
@@ -50,17 +51,24 @@ func (ic *InventoryController) CreateInventory(c *gin.Context) {
 		ID:        inventory.ID,
 		ProductID: inventory.ProductID,
 		Product: models.ProductResponse{
-			ID:         inventory.Product.ID,
-			Nama:       inventory.Product.Nama,
-			Deskripsi:  inventory.Product.Deskripsi,
-			Harga:      inventory.Product.Harga,
-			Kategori:   inventory.Product.Kategori,
-			FotoProduk: inventory.Product.FotoProduk,
-			CreatedAt:  inventory.Product.CreatedAt,
-			UpdatedAt:  inventory.Product.UpdatedAt,
+			ID:           inventory.Product.ID,
+			Nama:         inventory.Product.Nama,
+			Deskripsi:    inventory.Product.Deskripsi,
+			Harga:        inventory.Product.Harga,
+			MataUang:     inventory.Product.MataUang,
+			Kategori:     inventory.Product.Kategori,
+			Berat:        inventory.Product.Berat,
+			SKU:          inventory.Product.SKU,
+			ModeStok:     inventory.Product.ModeStok,
+			TersediaPada: inventory.Product.TersediaPada,
+			FotoProduk:   inventory.Product.FotoProduk,
+			Versi:        inventory.Product.Versi,
+			CreatedAt:    inventory.Product.CreatedAt,
+			UpdatedAt:    inventory.Product.UpdatedAt,
 		},
 		Jumlah:    inventory.Jumlah,
 		Lokasi:    inventory.Lokasi,
+		Versi:     inventory.Versi,
 		CreatedAt: inventory.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt: inventory.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
@@ -98,17 +106,24 @@ func (ic *InventoryController) GetInventories(c *gin.Context) {
 			ID:        inv.ID,
 			ProductID: inv.ProductID,
 			Product: models.ProductResponse{
-				ID:         inv.Product.ID,
-				Nama:       inv.Product.Nama,
-				Deskripsi:  inv.Product.Deskripsi,
-				Harga:      inv.Product.Harga,
-				Kategori:   inv.Product.Kategori,
-				FotoProduk: inv.Product.FotoProduk,
-				CreatedAt:  inv.Product.CreatedAt,
-				UpdatedAt:  inv.Product.UpdatedAt,
+				ID:           inv.Product.ID,
+				Nama:         inv.Product.Nama,
+				Deskripsi:    inv.Product.Deskripsi,
+				Harga:        inv.Product.Harga,
+				MataUang:     inv.Product.MataUang,
+				Kategori:     inv.Product.Kategori,
+				Berat:        inv.Product.Berat,
+				SKU:          inv.Product.SKU,
+				ModeStok:     inv.Product.ModeStok,
+				TersediaPada: inv.Product.TersediaPada,
+				FotoProduk:   inv.Product.FotoProduk,
+				Versi:        inv.Product.Versi,
+				CreatedAt:    inv.Product.CreatedAt,
+				UpdatedAt:    inv.Product.UpdatedAt,
 			},
 			Jumlah:    inv.Jumlah,
 			Lokasi:    inv.Lokasi,
+			Versi:     inv.Versi,
 			CreatedAt: inv.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: inv.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
@@ -154,21 +169,29 @@ func (ic *InventoryController) GetInventoryByID(c *gin.Context) {
 		ID:        inventory.ID,
 		ProductID: inventory.ProductID,
 		Product: models.ProductResponse{
-			ID:         inventory.Product.ID,
-			Nama:       inventory.Product.Nama,
-			Deskripsi:  inventory.Product.Deskripsi,
-			Harga:      inventory.Product.Harga,
-			Kategori:   inventory.Product.Kategori,
-			FotoProduk: inventory.Product.FotoProduk,
-			CreatedAt:  inventory.Product.CreatedAt,
-			UpdatedAt:  inventory.Product.UpdatedAt,
+			ID:           inventory.Product.ID,
+			Nama:         inventory.Product.Nama,
+			Deskripsi:    inventory.Product.Deskripsi,
+			Harga:        inventory.Product.Harga,
+			MataUang:     inventory.Product.MataUang,
+			Kategori:     inventory.Product.Kategori,
+			Berat:        inventory.Product.Berat,
+			SKU:          inventory.Product.SKU,
+			ModeStok:     inventory.Product.ModeStok,
+			TersediaPada: inventory.Product.TersediaPada,
+			FotoProduk:   inventory.Product.FotoProduk,
+			Versi:        inventory.Product.Versi,
+			CreatedAt:    inventory.Product.CreatedAt,
+			UpdatedAt:    inventory.Product.UpdatedAt,
 		},
 		Jumlah:    inventory.Jumlah,
 		Lokasi:    inventory.Lokasi,
+		Versi:     inventory.Versi,
 		CreatedAt: inventory.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt: inventory.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
 
+	setETag(c, inventory.Versi)
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "Inventory successfully found",
@@ -205,6 +228,11 @@ func (ic *InventoryController) UpdateInventory(c *gin.Context) {
 		return
 	}
 
+	versi, ok := requestVersion(c, req.Versi)
+	if !ok {
+		return
+	}
+
 	inventory, err := ic.InventoryService.GetInventoryByID(idUint)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.APIResponse{
@@ -219,9 +247,13 @@ func (ic *InventoryController) UpdateInventory(c *gin.Context) {
 		inventory.Lokasi = req.Lokasi
 	}
 
-	updatedInventory, err := ic.InventoryService.UpdateInventory(inventory)
+	updatedInventory, err := ic.InventoryService.UpdateInventory(inventory, versi)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.APIResponse{
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrVersionConflict) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.APIResponse{
 			Success: false,
 			Message: err.Error(),
 		})
@@ -232,21 +264,29 @@ func (ic *InventoryController) UpdateInventory(c *gin.Context) {
 		ID:        updatedInventory.ID,
 		ProductID: updatedInventory.ProductID,
 		Product: models.ProductResponse{
-			ID:         updatedInventory.Product.ID,
-			Nama:       updatedInventory.Product.Nama,
-			Deskripsi:  updatedInventory.Product.Deskripsi,
-			Harga:      updatedInventory.Product.Harga,
-			Kategori:   updatedInventory.Product.Kategori,
-			FotoProduk: updatedInventory.Product.FotoProduk,
-			CreatedAt:  updatedInventory.Product.CreatedAt,
-			UpdatedAt:  updatedInventory.Product.UpdatedAt,
+			ID:           updatedInventory.Product.ID,
+			Nama:         updatedInventory.Product.Nama,
+			Deskripsi:    updatedInventory.Product.Deskripsi,
+			Harga:        updatedInventory.Product.Harga,
+			MataUang:     updatedInventory.Product.MataUang,
+			Kategori:     updatedInventory.Product.Kategori,
+			Berat:        updatedInventory.Product.Berat,
+			SKU:          updatedInventory.Product.SKU,
+			ModeStok:     updatedInventory.Product.ModeStok,
+			TersediaPada: updatedInventory.Product.TersediaPada,
+			FotoProduk:   updatedInventory.Product.FotoProduk,
+			Versi:        updatedInventory.Product.Versi,
+			CreatedAt:    updatedInventory.Product.CreatedAt,
+			UpdatedAt:    updatedInventory.Product.UpdatedAt,
 		},
 		Jumlah:    updatedInventory.Jumlah,
 		Lokasi:    updatedInventory.Lokasi,
+		Versi:     updatedInventory.Versi,
 		CreatedAt: updatedInventory.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt: updatedInventory.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
 
+	setETag(c, updatedInventory.Versi)
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "Inventory successfully updated",
@@ -278,17 +318,24 @@ func (ic *InventoryController) UpdateStock(c *gin.Context) {
 		ID:        inventory.ID,
 		ProductID: inventory.ProductID,
 		Product: models.ProductResponse{
-			ID:         inventory.Product.ID,
-			Nama:       inventory.Product.Nama,
-			Deskripsi:  inventory.Product.Deskripsi,
-			Harga:      inventory.Product.Harga,
-			Kategori:   inventory.Product.Kategori,
-			FotoProduk: inventory.Product.FotoProduk,
-			CreatedAt:  inventory.Product.CreatedAt,
-			UpdatedAt:  inventory.Product.UpdatedAt,
+			ID:           inventory.Product.ID,
+			Nama:         inventory.Product.Nama,
+			Deskripsi:    inventory.Product.Deskripsi,
+			Harga:        inventory.Product.Harga,
+			MataUang:     inventory.Product.MataUang,
+			Kategori:     inventory.Product.Kategori,
+			Berat:        inventory.Product.Berat,
+			SKU:          inventory.Product.SKU,
+			ModeStok:     inventory.Product.ModeStok,
+			TersediaPada: inventory.Product.TersediaPada,
+			FotoProduk:   inventory.Product.FotoProduk,
+			Versi:        inventory.Product.Versi,
+			CreatedAt:    inventory.Product.CreatedAt,
+			UpdatedAt:    inventory.Product.UpdatedAt,
 		},
 		Jumlah:    inventory.Jumlah,
 		Lokasi:    inventory.Lokasi,
+		Versi:     inventory.Versi,
 		CreatedAt: inventory.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt: inventory.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type InvoiceController struct {
+	InvoiceService *services.InvoiceService
+}
+
+func NewInvoiceController(db *gorm.DB) *InvoiceController {
+	return &InvoiceController{
+		InvoiceService: services.NewInvoiceService(db),
+	}
+}
+
+func (ic *InvoiceController) DownloadInvoice(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	invoice, err := ic.InvoiceService.GetInvoice(idUint, userID.(uint))
+	if err != nil {
+		status := http.StatusNotFound
+		if err.Error() == "invoices are only issued for paid orders" {
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	fileName := strings.ReplaceAll(invoice.NomorInvoice, "/", "-") + ".pdf"
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", fileName))
+	c.Data(http.StatusOK, "application/pdf", ic.InvoiceService.RenderPDF(invoice))
+}
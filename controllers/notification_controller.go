@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type NotificationController struct {
+	NotificationService *services.NotificationService
+}
+
+func NewNotificationController(db *gorm.DB) *NotificationController {
+	return &NotificationController{
+		NotificationService: services.NewNotificationService(db),
+	}
+}
+
+func (nc *NotificationController) GetNotificationPreferences(c *gin.Context) {
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	preference, err := nc.NotificationService.GetPreference(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notification preferences successfully retrieved",
+		Data:    nc.convertToNotificationPreferenceResponse(preference),
+	})
+}
+
+func (nc *NotificationController) UpdateNotificationPreferences(c *gin.Context) {
+	var req models.UpdateNotificationPreferenceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	preference, err := nc.NotificationService.UpdatePreference(userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notification preferences successfully updated",
+		Data:    nc.convertToNotificationPreferenceResponse(preference),
+	})
+}
+
+func (nc *NotificationController) GetNotifications(c *gin.Context) {
+	var req models.GetNotificationRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	notifications, total, err := nc.NotificationService.GetNotifications(userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.NotificationResponse
+	for _, notification := range notifications {
+		response := models.NotificationResponse{
+			ID:        notification.ID,
+			OrderID:   notification.OrderID,
+			Jenis:     notification.Jenis,
+			Penerima:  notification.Penerima,
+			Subjek:    notification.Subjek,
+			Isi:       notification.Isi,
+			Status:    notification.Status,
+			CreatedAt: notification.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+
+		if notification.TerkirimPada != nil {
+			response.TerkirimPada = notification.TerkirimPada.Format("2006-01-02 15:04:05")
+		}
+
+		responses = append(responses, response)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notifications successfully retrieved",
+		Data:    responses,
+		Meta: &models.PaginationMeta{
+			Total:  total,
+			Limit:  req.Limit,
+			Offset: req.Offset,
+		},
+	})
+}
+
+func (nc *NotificationController) convertToNotificationPreferenceResponse(preference *models.NotificationPreference) models.NotificationPreferenceResponse {
+	return models.NotificationPreferenceResponse{
+		Bahasa:           preference.Bahasa,
+		EmailOrderBaru:   preference.EmailOrderBaru,
+		EmailStatusOrder: preference.EmailStatusOrder,
+	}
+}
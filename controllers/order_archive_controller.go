@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (oc *OrderController) GetDeletedOrders(c *gin.Context) {
+	var req models.GetDeletedOrderRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	orders, total, err := oc.OrderService.GetDeletedOrders(&req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.OrderResponse
+	for _, order := range orders {
+		responses = append(responses, oc.convertToOrderResponse(&order))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Deleted orders successfully retrieved",
+		Data:    responses,
+		Meta: &models.PaginationMeta{
+			Total:  total,
+			Limit:  req.Limit,
+			Offset: req.Offset,
+		},
+	})
+}
+
+func (oc *OrderController) RestoreOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	order, err := oc.OrderService.RestoreOrder(idUint, userID.(uint))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "deleted order not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order successfully restored",
+		Data:    oc.convertToOrderResponse(order),
+	})
+}
+
+func (oc *OrderController) PurgeDeletedOrders(c *gin.Context) {
+	batas := time.Now().Add(-config.GetOrderDeletedRetention())
+
+	purged, err := oc.OrderService.PurgeDeletedOrders(batas)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Deleted orders successfully purged",
+		Data: models.OrderMaintenanceResponse{
+			Jumlah: purged,
+			Batas:  batas.Format("2006-01-02 15:04:05"),
+		},
+	})
+}
+
+func (oc *OrderController) ArchiveOrders(c *gin.Context) {
+	batas := time.Now().Add(-config.GetOrderArchiveAfter())
+
+	archived, err := oc.OrderService.ArchiveOrders(batas)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Orders successfully archived",
+		Data: models.OrderMaintenanceResponse{
+			Jumlah: archived,
+			Batas:  batas.Format("2006-01-02 15:04:05"),
+		},
+	})
+}
+
+func (oc *OrderController) GetArchivedOrders(c *gin.Context) {
+	var req models.GetArchivedOrderRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	orders, total, err := oc.OrderService.GetArchivedOrders(&req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.ArchivedOrderResponse
+	for _, order := range orders {
+		responses = append(responses, oc.convertToArchivedOrderResponse(&order))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Archived orders successfully retrieved",
+		Data:    responses,
+		Meta: &models.PaginationMeta{
+			Total:  total,
+			Limit:  req.Limit,
+			Offset: req.Offset,
+		},
+	})
+}
+
+func (oc *OrderController) GetArchivedOrderByID(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	order, err := oc.OrderService.GetArchivedOrderByID(idUint)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Archived order successfully found",
+		Data:    oc.convertToArchivedOrderResponse(order),
+	})
+}
+
+func (oc *OrderController) convertToArchivedOrderResponse(order *models.ArchivedOrder) models.ArchivedOrderResponse {
+	var items []models.ArchivedOrderItemResponse
+	for _, item := range order.Items {
+		items = append(items, models.ArchivedOrderItemResponse{
+			ID:         item.ID,
+			ProductID:  item.ProductID,
+			NamaProduk: item.NamaProduk,
+			Jumlah:     item.Jumlah,
+			Harga:      item.Harga,
+			Subtotal:   item.Subtotal,
+			TarifPajak: item.TarifPajak,
+			Pajak:      item.Pajak,
+		})
+	}
+
+	var refunds []models.ArchivedRefundResponse
+	for _, refund := range order.Refunds {
+		refunds = append(refunds, models.ArchivedRefundResponse{
+			ID:              refund.ID,
+			ReturnRequestID: refund.ReturnRequestID,
+			Nominal:         refund.Nominal,
+			Alasan:          refund.Alasan,
+			DiprosesOleh:    refund.DiprosesOleh,
+			DibuatPada:      refund.DibuatPada.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	response := models.ArchivedOrderResponse{
+		ID:                  order.ID,
+		NomorOrder:          order.NomorOrder,
+		UserID:              order.UserID,
+		NamaPelanggan:       order.NamaPelanggan,
+		EmailPelanggan:      order.EmailPelanggan,
+		MataUang:            order.MataUang,
+		Kurs:                order.Kurs,
+		Subtotal:            order.Subtotal,
+		TotalPajak:          order.TotalPajak,
+		HargaTermasukPajak:  order.HargaTermasukPajak,
+		AlamatPengiriman:    order.AlamatPengiriman,
+		MetodePengiriman:    order.MetodePengiriman,
+		BeratTotal:          order.BeratTotal,
+		OngkosKirim:         order.OngkosKirim,
+		TotalHarga:          order.TotalHarga,
+		TotalRefund:         order.TotalRefund,
+		TotalBersih:         order.TotalBersih,
+		Status:              order.Status,
+		NomorInvoice:        order.NomorInvoice,
+		ReferensiPembayaran: order.ReferensiPembayaran,
+		NomorResi:           order.NomorResi,
+		TanggalOrder:        order.TanggalOrder.Format("2006-01-02 15:04:05"),
+		DibuatPada:          order.DibuatPada.Format("2006-01-02 15:04:05"),
+		DiarsipkanPada:      order.DiarsipkanPada.Format("2006-01-02 15:04:05"),
+		Items:               items,
+		Refunds:             refunds,
+	}
+
+	if order.TanggalInvoice != nil {
+		response.TanggalInvoice = order.TanggalInvoice.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (oc *OrderController) GetOrderComments(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	comments, err := oc.OrderService.GetOrderComments(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order comments successfully retrieved",
+		Data:    oc.convertToOrderCommentResponses(comments),
+	})
+}
+
+func (oc *OrderController) AddOrderComment(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.AddOrderCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	comment, err := oc.OrderService.AddOrderComment(idUint, userID.(uint), &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "order not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Order comment successfully added",
+		Data:    oc.convertToOrderCommentResponse(comment),
+	})
+}
+
+func (oc *OrderController) GetStaffOrderComments(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	comments, err := oc.OrderService.GetStaffOrderComments(idUint)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order comments successfully retrieved",
+		Data:    oc.convertToOrderCommentResponses(comments),
+	})
+}
+
+func (oc *OrderController) AddStaffOrderComment(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.AddOrderCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	staffID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	comment, err := oc.OrderService.AddStaffOrderComment(idUint, staffID.(uint), &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "order not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Order comment successfully added",
+		Data:    oc.convertToOrderCommentResponse(comment),
+	})
+}
+
+func (oc *OrderController) convertToOrderCommentResponses(comments []models.OrderComment) []models.OrderCommentResponse {
+	var responses []models.OrderCommentResponse
+	for _, comment := range comments {
+		responses = append(responses, oc.convertToOrderCommentResponse(&comment))
+	}
+
+	return responses
+}
+
+func (oc *OrderController) convertToOrderCommentResponse(comment *models.OrderComment) models.OrderCommentResponse {
+	return models.OrderCommentResponse{
+		ID:           comment.ID,
+		OrderID:      comment.OrderID,
+		UserID:       comment.UserID,
+		NamaPenulis:  comment.User.Name,
+		PeranPenulis: comment.User.Role,
+		Isi:          comment.Isi,
+		Visibilitas:  comment.Visibilitas,
+		CreatedAt:    comment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -1,10 +1,12 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"golang-api/models"
 	"golang-api/services"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -80,7 +82,7 @@ func (oc *OrderController) GetOrders(c *gin.Context) {
 
 	req.UserID = userID.(uint)
 
-	orders, err := oc.OrderService.GetOrders(&req)
+	orders, total, err := oc.OrderService.GetOrders(&req)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
@@ -98,6 +100,11 @@ func (oc *OrderController) GetOrders(c *gin.Context) {
 		Success: true,
 		Message: "Orders successfully retrieved",
 		Data:    responses,
+		Meta: &models.PaginationMeta{
+			Total:  total,
+			Limit:  req.Limit,
+			Offset: req.Offset,
+		},
 	})
 }
 
@@ -111,26 +118,104 @@ func (oc *OrderController) GetOrderByID(c *gin.Context) {
 		return
 	}
 
-	var idUint uint
-	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	var order *models.Order
+	var err error
+	if idUint, parseErr := strconv.ParseUint(idStr, 10, 64); parseErr == nil {
+		order, err = oc.OrderService.GetOrderByIDAndUserID(uint(idUint), userID.(uint))
+	} else {
+		order, err = oc.OrderService.GetOrderByNumberAndUserID(idStr, userID.(uint))
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	comments, err := oc.OrderService.ListOrderComments(order.ID, false)
 	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response := oc.convertToOrderResponse(order)
+	response.Komentar = oc.convertToOrderCommentResponses(comments)
+	setETag(c, order.Versi)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order successfully found",
+		Data:    response,
+	})
+}
+
+func (oc *OrderController) GetAllOrders(c *gin.Context) {
+	var req models.GetOrderRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
-			Message: "ID must be a valid number",
+			Message: "invalid query parameters: " + err.Error(),
 		})
 		return
 	}
 
-	userID, exists := c.Get("userId")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, models.APIResponse{
+	orders, total, err := oc.OrderService.GetOrders(&req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
-			Message: "user not authenticated",
+			Message: err.Error(),
 		})
 		return
 	}
 
-	order, err := oc.OrderService.GetOrderByIDAndUserID(idUint, userID.(uint))
+	var responses []models.OrderResponse
+	for _, order := range orders {
+		responses = append(responses, oc.convertToOrderResponse(&order))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Orders successfully retrieved",
+		Data:    responses,
+		Meta: &models.PaginationMeta{
+			Total:  total,
+			Limit:  req.Limit,
+			Offset: req.Offset,
+		},
+	})
+}
+
+func (oc *OrderController) GetAnyOrderByID(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var order *models.Order
+	var err error
+	if idUint, parseErr := strconv.ParseUint(idStr, 10, 64); parseErr == nil {
+		order, err = oc.OrderService.GetOrderByID(uint(idUint))
+	} else {
+		order, err = oc.OrderService.GetOrderByNumber(idStr)
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
@@ -139,7 +224,18 @@ func (oc *OrderController) GetOrderByID(c *gin.Context) {
 		return
 	}
 
+	comments, err := oc.OrderService.ListOrderComments(order.ID, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	response := oc.convertToOrderResponse(order)
+	response.Komentar = oc.convertToOrderCommentResponses(comments)
+	setETag(c, order.Versi)
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -177,9 +273,29 @@ func (oc *OrderController) UpdateOrderStatus(c *gin.Context) {
 		return
 	}
 
-	order, err := oc.OrderService.UpdateOrderStatus(idUint, req.Status)
+	versi, ok := requestVersion(c, req.Versi)
+	if !ok {
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	order, err := oc.OrderService.UpdateOrderStatus(idUint, userID.(uint), &req, versi)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.APIResponse{
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrVersionConflict) {
+			status = http.StatusConflict
+		} else if err.Error() == "order not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
 			Success: false,
 			Message: err.Error(),
 		})
@@ -187,6 +303,7 @@ func (oc *OrderController) UpdateOrderStatus(c *gin.Context) {
 	}
 
 	response := oc.convertToOrderResponse(order)
+	setETag(c, order.Versi)
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -230,42 +347,438 @@ func (oc *OrderController) DeleteOrder(c *gin.Context) {
 	})
 }
 
+func (oc *OrderController) AddOrderItem(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.AddOrderItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	order, err := oc.OrderService.AddOrderItem(idUint, userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order item successfully added",
+		Data:    oc.convertToOrderResponse(order),
+	})
+}
+
+func (oc *OrderController) UpdateOrderItem(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var itemID uint
+	if _, err := fmt.Sscanf(c.Param("itemId"), "%d", &itemID); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "item ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.UpdateOrderItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	order, err := oc.OrderService.UpdateOrderItem(idUint, itemID, userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order item successfully updated",
+		Data:    oc.convertToOrderResponse(order),
+	})
+}
+
+func (oc *OrderController) RemoveOrderItem(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var itemID uint
+	if _, err := fmt.Sscanf(c.Param("itemId"), "%d", &itemID); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "item ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	order, err := oc.OrderService.RemoveOrderItem(idUint, itemID, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order item successfully removed",
+		Data:    oc.convertToOrderResponse(order),
+	})
+}
+
+func (oc *OrderController) CancelOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.CancelOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	order, err := oc.OrderService.CancelOrder(idUint, userID.(uint), &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "order not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order successfully cancelled",
+		Data:    oc.convertToOrderResponse(order),
+	})
+}
+
+func (oc *OrderController) Reorder(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	order, adjustments, err := oc.OrderService.Reorder(idUint, userID.(uint))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "order not found" {
+			status = http.StatusNotFound
+		}
+		response := models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+		if len(adjustments) > 0 {
+			response.Data = adjustments
+		}
+		c.JSON(status, response)
+		return
+	}
+
+	if adjustments == nil {
+		adjustments = []models.ReorderAdjustment{}
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Order successfully reordered",
+		Data: models.ReorderResponse{
+			Order:       oc.convertToOrderResponse(order),
+			Penyesuaian: adjustments,
+		},
+	})
+}
+
+func (oc *OrderController) GetOrderChanges(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	changes, err := oc.OrderService.GetOrderChanges(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.OrderChangeResponse
+	for _, change := range changes {
+		responses = append(responses, models.OrderChangeResponse{
+			ID:            change.ID,
+			OrderID:       change.OrderID,
+			UserID:        change.UserID,
+			Aksi:          change.Aksi,
+			OrderItemID:   change.OrderItemID,
+			ProductID:     change.ProductID,
+			JumlahSebelum: change.JumlahSebelum,
+			JumlahSesudah: change.JumlahSesudah,
+			TotalSebelum:  change.TotalSebelum,
+			TotalSesudah:  change.TotalSesudah,
+			Keterangan:    change.Keterangan,
+			CreatedAt:     change.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order changes successfully retrieved",
+		Data:    responses,
+	})
+}
+
 func (oc *OrderController) convertToOrderResponse(order *models.Order) models.OrderResponse {
 	var orderItems []models.OrderItemResponse
 	for _, item := range order.OrderItems {
+		var tersediaPada string
+		if item.TersediaPada != nil {
+			tersediaPada = item.TersediaPada.Format("2006-01-02")
+		}
+
 		orderItems = append(orderItems, models.OrderItemResponse{
 			ID:        item.ID,
 			OrderID:   item.OrderID,
 			ProductID: item.ProductID,
 			Product: models.ProductResponse{
-				ID:         item.Product.ID,
-				Nama:       item.Product.Nama,
-				Deskripsi:  item.Product.Deskripsi,
-				Harga:      item.Product.Harga,
-				Kategori:   item.Product.Kategori,
-				FotoProduk: item.Product.FotoProduk,
-				CreatedAt:  item.Product.CreatedAt,
-				UpdatedAt:  item.Product.UpdatedAt,
+				ID:           item.Product.ID,
+				Nama:         item.Product.Nama,
+				Deskripsi:    item.Product.Deskripsi,
+				Harga:        item.Product.Harga,
+				MataUang:     item.Product.MataUang,
+				Kategori:     item.Product.Kategori,
+				Berat:        item.Product.Berat,
+				SKU:          item.Product.SKU,
+				ModeStok:     item.Product.ModeStok,
+				TersediaPada: item.Product.TersediaPada,
+				FotoProduk:   item.Product.FotoProduk,
+				Versi:        item.Product.Versi,
+				CreatedAt:    item.Product.CreatedAt,
+				UpdatedAt:    item.Product.UpdatedAt,
 			},
-			Jumlah:   item.Jumlah,
-			Harga:    item.Harga,
-			Subtotal: item.Subtotal,
+			Jumlah:          item.Jumlah,
+			JumlahDikirim:   item.JumlahDikirim,
+			JumlahBackorder: item.JumlahBackorder,
+			JenisBackorder:  item.JenisBackorder,
+			TersediaPada:    tersediaPada,
+			Harga:           item.Harga,
+			Subtotal:        item.Subtotal,
+			TarifPajak:      item.TarifPajak,
+			Pajak:           item.Pajak,
 		})
 	}
 
-	return models.OrderResponse{
-		ID:     order.ID,
-		UserID: order.UserID,
+	response := models.OrderResponse{
+		ID:         order.ID,
+		NomorOrder: order.NomorOrder,
+		UserID:     order.UserID,
 		User: models.UserResponse{
 			ID:    order.User.ID,
 			Name:  order.User.Name,
 			Email: order.User.Email,
 		},
-		TotalHarga:   order.TotalHarga,
-		Status:       order.Status,
-		TanggalOrder: order.TanggalOrder.Format("2006-01-02 15:04:05"),
-		OrderItems:   orderItems,
-		CreatedAt:    order.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:    order.UpdatedAt.Format("2006-01-02 15:04:05"),
+		SubscriptionID:     order.SubscriptionID,
+		MataUang:           order.MataUang,
+		Kurs:               order.Kurs,
+		Subtotal:           order.Subtotal,
+		TotalPajak:         order.TotalPajak,
+		HargaTermasukPajak: order.HargaTermasukPajak,
+		AlamatPengiriman:   order.AlamatPengiriman,
+		MetodePengiriman:   order.MetodePengiriman,
+		BeratTotal:         order.BeratTotal,
+		OngkosKirim:        order.OngkosKirim,
+		TotalHarga:         order.TotalHarga,
+		TotalRefund:        order.TotalRefund,
+		TotalBersih:        order.TotalBersih,
+		Status:             order.Status,
+		Versi:              order.Versi,
+		TanggalOrder:       order.TanggalOrder.Format("2006-01-02 15:04:05"),
+		DibatalkanOleh:     order.DibatalkanOleh,
+		AlasanPembatalan:   order.AlasanPembatalan,
+		KomentarPembatalan: order.KomentarPembatalan,
+		OrderItems:         orderItems,
+		CreatedAt:          order.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:          order.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
+
+	if order.DibatalkanPada != nil {
+		response.DibatalkanPada = order.DibatalkanPada.Format("2006-01-02 15:04:05")
+	}
+
+	if order.DeletedAt.Valid {
+		response.DihapusPada = order.DeletedAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	return response
 }
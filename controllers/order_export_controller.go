@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (oc *OrderController) ExportOrders(c *gin.Context) {
+	var req models.ExportOrderRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	req.UserID = userID.(uint)
+
+	oc.writeOrderExport(c, &req)
+}
+
+func (oc *OrderController) ExportAllOrders(c *gin.Context) {
+	var req models.ExportOrderRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	oc.writeOrderExport(c, &req)
+}
+
+func (oc *OrderController) writeOrderExport(c *gin.Context, req *models.ExportOrderRequest) {
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	fileName := fmt.Sprintf("orders-%s.%s", time.Now().Format("20060102-150405"), req.Format)
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	if err := oc.OrderService.ExportOrders(req, c.Writer); err != nil {
+		// Once rows have been sent the status is already out; the truncated file is all we can do.
+		if c.Writer.Written() {
+			log.Println("Error exporting orders: " + err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "error exporting orders: " + err.Error(),
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"golang-api/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (oc *OrderController) GuestCheckout(c *gin.Context) {
+	var req models.GuestCheckoutRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	order, err := oc.OrderService.GuestCheckout(&req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "email is already registered, log in to place the order" {
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	link, berlakuSampai := oc.OrderService.GuestOrderLink(order.NomorOrder)
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Order successfully created",
+		Data: models.GuestCheckoutResponse{
+			Order:         oc.convertToOrderResponse(order),
+			LinkPelacakan: link,
+			BerlakuSampai: berlakuSampai.Format("2006-01-02 15:04:05"),
+		},
+	})
+}
+
+func (oc *OrderController) GetGuestOrder(c *gin.Context) {
+	var req models.GuestOrderLinkRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	order, err := oc.OrderService.GetGuestOrder(c.Param("nomor"), &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "invalid order link", "order link has expired":
+			status = http.StatusForbidden
+		case "order not found":
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Order successfully found",
+		Data:    oc.convertToOrderResponse(order),
+	})
+}
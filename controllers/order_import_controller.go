@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"errors"
+	"golang-api/config"
+	"golang-api/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (oc *OrderController) ImportOrders(c *gin.Context) {
+	var req models.ImportOrderRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.GetOrderImportMaxSize())
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+				Success: false,
+				Message: "CSV file is too large",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "CSV file is required",
+		})
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "error opening file",
+		})
+		return
+	}
+	defer src.Close()
+
+	result, err := oc.OrderService.ImportOrders(src, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	switch {
+	case req.DryRun:
+		message := "Order import is valid"
+		if len(result.Errors) > 0 {
+			message = "Order import contains errors"
+		}
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: len(result.Errors) == 0,
+			Message: message,
+			Data:    result,
+		})
+	case result.OrderDibuat == 0:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Order import failed, no orders were created",
+			Data:    result,
+		})
+	default:
+		c.JSON(http.StatusCreated, models.APIResponse{
+			Success: true,
+			Message: "Orders successfully imported",
+			Data:    result,
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PaymentController struct {
+	PaymentService *services.PaymentService
+}
+
+func NewPaymentController(db *gorm.DB) *PaymentController {
+	return &PaymentController{
+		PaymentService: services.NewPaymentService(db),
+	}
+}
+
+func (pc *PaymentController) CreatePayment(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.CreatePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	payment, err := pc.PaymentService.CreatePayment(idUint, userID.(uint), req.Gateway)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Payment successfully created",
+		Data:    pc.convertToPaymentResponse(payment),
+	})
+}
+
+func (pc *PaymentController) CreateGuestPayment(c *gin.Context) {
+	var link models.GuestOrderLinkRequest
+	if err := c.ShouldBindQuery(&link); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	var req models.CreatePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	payment, err := pc.PaymentService.CreateGuestPayment(c.Param("nomor"), &link, req.Gateway)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch err.Error() {
+		case "invalid order link", "order link has expired":
+			status = http.StatusForbidden
+		case "order not found":
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Payment successfully created",
+		Data:    pc.convertToPaymentResponse(payment),
+	})
+}
+
+func (pc *PaymentController) GetPaymentsByOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	payments, err := pc.PaymentService.GetPaymentsByOrder(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.PaymentResponse
+	for _, payment := range payments {
+		responses = append(responses, pc.convertToPaymentResponse(&payment))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Payments successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (pc *PaymentController) HandleWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "error reading webhook payload",
+		})
+		return
+	}
+
+	payment, err := pc.PaymentService.HandleWebhook(c.Param("gateway"), payload, c.GetHeader("X-Signature"))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "invalid webhook signature" {
+			status = http.StatusUnauthorized
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook successfully processed",
+		Data:    pc.convertToPaymentResponse(payment),
+	})
+}
+
+func (pc *PaymentController) SimulateMockPayment(c *gin.Context) {
+	var req models.SimulatePaymentRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "invalid data: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	if req.Status == "" {
+		req.Status = "settled"
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	payment, err := pc.PaymentService.SimulateMockPayment(c.Param("reference"), userID.(uint), req.Status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Mock payment successfully processed",
+		Data:    pc.convertToPaymentResponse(payment),
+	})
+}
+
+func (pc *PaymentController) convertToPaymentResponse(payment *models.Payment) models.PaymentResponse {
+	response := models.PaymentResponse{
+		ID:         payment.ID,
+		OrderID:    payment.OrderID,
+		Gateway:    payment.Gateway,
+		Nominal:    payment.Nominal,
+		MataUang:   payment.MataUang,
+		Status:     payment.Status,
+		Referensi:  payment.Referensi,
+		PaymentURL: payment.PaymentURL,
+		CreatedAt:  payment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:  payment.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if payment.DibayarPada != nil {
+		response.DibayarPada = payment.DibayarPada.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
@@ -1,13 +1,14 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"golang-api/models"
 	"golang-api/services"
 	"mime/multipart"
 	"net/http"
-	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -81,9 +82,19 @@ func (pc *ProductController) CreateProduct(c *gin.Context) {
 	newProduct := models.Product{
 		Nama:       req.Nama,
 		Deskripsi:  req.Deskripsi,
-		Harga:      float64(req.Harga),
+		Harga:      req.Harga,
+		MataUang:   req.MataUang,
 		Kategori:   req.Kategori,
+		Berat:      req.Berat,
 		FotoProduk: fileName,
+		ModeStok:   req.ModeStok,
+	}
+
+	if !req.TersediaPada.IsZero() {
+		newProduct.TersediaPada = &req.TersediaPada
+	}
+	if sku := strings.TrimSpace(req.SKU); sku != "" {
+		newProduct.SKU = &sku
 	}
 
 	product, err := pc.ProductService.CreateProduct(&newProduct, src)
@@ -107,14 +118,20 @@ func (pc *ProductController) CreateProduct(c *gin.Context) {
 		Success: true,
 		Message: "Product successfully created",
 		Data: models.ProductResponse{
-			ID:         product.ID,
-			Nama:       product.Nama,
-			Deskripsi:  product.Deskripsi,
-			Harga:      product.Harga,
-			Kategori:   product.Kategori,
-			FotoProduk: product.FotoProduk,
-			CreatedAt:  product.CreatedAt,
-			UpdatedAt:  product.CreatedAt,
+			ID:           product.ID,
+			Nama:         product.Nama,
+			Deskripsi:    product.Deskripsi,
+			Harga:        product.Harga,
+			MataUang:     product.MataUang,
+			Kategori:     product.Kategori,
+			Berat:        product.Berat,
+			SKU:          product.SKU,
+			ModeStok:     product.ModeStok,
+			TersediaPada: product.TersediaPada,
+			FotoProduk:   product.FotoProduk,
+			Versi:        product.Versi,
+			CreatedAt:    product.CreatedAt,
+			UpdatedAt:    product.CreatedAt,
 		},
 	})
 
@@ -141,9 +158,21 @@ func (pc *ProductController) GetProduct(c *gin.Context) {
 		return
 	}
 
+	var prices []models.Money
+	if req.Currency != "" {
+		prices, err = pc.ProductService.ConvertPrices(products, req.Currency)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
 	var responses []models.ProductResponse
 
-	for _, p := range products {
+	for i, p := range products {
 		if p.FotoProduk != "" {
 			fotoLink := fmt.Sprintf("http://%s/%s", c.Request.Host, "api/products/images/"+p.FotoProduk)
 			p.FotoProduk = fotoLink
@@ -151,16 +180,29 @@ func (pc *ProductController) GetProduct(c *gin.Context) {
 			p.FotoProduk = fmt.Sprintf("http://%s/%s", c.Request.Host, "api/products/images/default.png")
 		}
 
-		responses = append(responses, models.ProductResponse{
-			ID:         p.ID,
-			Nama:       p.Nama,
-			Deskripsi:  p.Deskripsi,
-			Harga:      p.Harga,
-			Kategori:   p.Kategori,
-			FotoProduk: p.FotoProduk,
-			CreatedAt:  p.CreatedAt,
-			UpdatedAt:  p.UpdatedAt,
-		})
+		response := models.ProductResponse{
+			ID:           p.ID,
+			Nama:         p.Nama,
+			Deskripsi:    p.Deskripsi,
+			Harga:        p.Harga,
+			MataUang:     p.MataUang,
+			Kategori:     p.Kategori,
+			Berat:        p.Berat,
+			SKU:          p.SKU,
+			ModeStok:     p.ModeStok,
+			TersediaPada: p.TersediaPada,
+			FotoProduk:   p.FotoProduk,
+			Versi:        p.Versi,
+			CreatedAt:    p.CreatedAt,
+			UpdatedAt:    p.UpdatedAt,
+		}
+
+		if prices != nil {
+			response.HargaKonversi = &prices[i]
+			response.MataUangKonversi = strings.ToUpper(strings.TrimSpace(req.Currency))
+		}
+
+		responses = append(responses, response)
 	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
@@ -206,19 +248,42 @@ func (pc *ProductController) GetProductByID(c *gin.Context) {
 		product.FotoProduk = fmt.Sprintf("http://%s/%s", c.Request.Host, "api/products/images/default.png")
 	}
 
+	response := models.ProductResponse{
+		ID:           product.ID,
+		Nama:         product.Nama,
+		Deskripsi:    product.Deskripsi,
+		Harga:        product.Harga,
+		MataUang:     product.MataUang,
+		Kategori:     product.Kategori,
+		Berat:        product.Berat,
+		SKU:          product.SKU,
+		ModeStok:     product.ModeStok,
+		TersediaPada: product.TersediaPada,
+		FotoProduk:   product.FotoProduk,
+		Versi:        product.Versi,
+		CreatedAt:    product.CreatedAt,
+		UpdatedAt:    product.UpdatedAt,
+	}
+
+	if currency := c.Query("currency"); currency != "" {
+		prices, err := pc.ProductService.ConvertPrices([]models.Product{*product}, currency)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		response.HargaKonversi = &prices[0]
+		response.MataUangKonversi = strings.ToUpper(strings.TrimSpace(currency))
+	}
+
+	setETag(c, product.Versi)
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "Product successfully found",
-		Data: models.ProductResponse{
-			ID:         product.ID,
-			Nama:       product.Nama,
-			Deskripsi:  product.Deskripsi,
-			Harga:      product.Harga,
-			Kategori:   product.Kategori,
-			FotoProduk: product.FotoProduk,
-			CreatedAt:  product.CreatedAt,
-			UpdatedAt:  product.UpdatedAt,
-		},
+		Data:    response,
 	})
 }
 
@@ -252,6 +317,11 @@ func (pc *ProductController) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	versi, ok := requestVersion(c, req.Versi)
+	if !ok {
+		return
+	}
+
 	file, err := c.FormFile("foto_produk")
 	var fileName string
 	var src multipart.File
@@ -304,31 +374,44 @@ func (pc *ProductController) UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	if product.FotoProduk != "" && fileName != "" {
-		oldFilePath := filepath.Join("uploads", product.FotoProduk)
-		if _, err := os.Stat(oldFilePath); err == nil {
-			err := os.Remove(oldFilePath)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, models.APIResponse{
-					Success: false,
-					Message: "error deleting old file",
-				})
-				return
-			}
-		}
+	if product.Versi != versi {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Message: services.ErrVersionConflict.Error(),
+		})
+		return
 	}
 
+	fotoLama := product.FotoProduk
+
 	product.Nama = req.Nama
-	product.Harga = float64(req.Harga)
+	product.Harga = req.Harga
+	if req.MataUang != "" {
+		product.MataUang = req.MataUang
+	}
 	product.Kategori = req.Kategori
+	product.Berat = req.Berat
+	if req.ModeStok != "" {
+		product.ModeStok = req.ModeStok
+	}
+	if !req.TersediaPada.IsZero() {
+		product.TersediaPada = &req.TersediaPada
+	}
+	if sku := strings.TrimSpace(req.SKU); sku != "" {
+		product.SKU = &sku
+	}
 	product.Deskripsi = req.Deskripsi
 	if fileName != "" {
 		product.FotoProduk = fileName
 	}
 
-	updatedProduct, err := pc.ProductService.UpdateProduct(product, src)
+	updatedProduct, err := pc.ProductService.UpdateProduct(product, src, versi, fotoLama)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrVersionConflict) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, models.APIResponse{
 			Success: false,
 			Message: err.Error(),
 		})
@@ -341,18 +424,25 @@ func (pc *ProductController) UpdateProduct(c *gin.Context) {
 		updatedProduct.FotoProduk = fmt.Sprintf("http://%s/%s", c.Request.Host, "api/products/images/default.png")
 	}
 
+	setETag(c, updatedProduct.Versi)
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "Product successfully updated",
 		Data: models.ProductResponse{
-			ID:         updatedProduct.ID,
-			Nama:       updatedProduct.Nama,
-			Deskripsi:  updatedProduct.Deskripsi,
-			Harga:      updatedProduct.Harga,
-			Kategori:   updatedProduct.Kategori,
-			FotoProduk: updatedProduct.FotoProduk,
-			CreatedAt:  updatedProduct.CreatedAt,
-			UpdatedAt:  updatedProduct.UpdatedAt,
+			ID:           updatedProduct.ID,
+			Nama:         updatedProduct.Nama,
+			Deskripsi:    updatedProduct.Deskripsi,
+			Harga:        updatedProduct.Harga,
+			MataUang:     updatedProduct.MataUang,
+			Kategori:     updatedProduct.Kategori,
+			Berat:        updatedProduct.Berat,
+			SKU:          updatedProduct.SKU,
+			ModeStok:     updatedProduct.ModeStok,
+			TersediaPada: updatedProduct.TersediaPada,
+			FotoProduk:   updatedProduct.FotoProduk,
+			Versi:        updatedProduct.Versi,
+			CreatedAt:    updatedProduct.CreatedAt,
+			UpdatedAt:    updatedProduct.UpdatedAt,
 		},
 	})
 }
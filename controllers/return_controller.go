@@ -0,0 +1,486 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReturnController struct {
+	ReturnService *services.ReturnService
+}
+
+func NewReturnController(db *gorm.DB) *ReturnController {
+	return &ReturnController{
+		ReturnService: services.NewReturnService(db),
+	}
+}
+
+func (rc *ReturnController) CreateReturn(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	returnRequest, err := rc.ReturnService.CreateReturn(idUint, userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Return request successfully created",
+		Data:    rc.convertToReturnResponse(returnRequest),
+	})
+}
+
+func (rc *ReturnController) GetReturnsByOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	returns, err := rc.ReturnService.GetReturnsByOrder(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.ReturnResponse
+	for _, returnRequest := range returns {
+		responses = append(responses, rc.convertToReturnResponse(&returnRequest))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Return requests successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (rc *ReturnController) GetReturns(c *gin.Context) {
+	var req models.GetReturnRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	returns, err := rc.ReturnService.GetReturns(&req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.ReturnResponse
+	for _, returnRequest := range returns {
+		responses = append(responses, rc.convertToReturnResponse(&returnRequest))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Return requests successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (rc *ReturnController) GetReturnByID(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	returnRequest, err := rc.ReturnService.GetReturnByID(idUint)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Return request successfully found",
+		Data:    rc.convertToReturnResponse(returnRequest),
+	})
+}
+
+func (rc *ReturnController) ApproveReturn(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.ReviewReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	returnRequest, err := rc.ReturnService.ApproveReturn(idUint, userID.(uint), req.CatatanStaff)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Return request successfully approved",
+		Data:    rc.convertToReturnResponse(returnRequest),
+	})
+}
+
+func (rc *ReturnController) RejectReturn(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.ReviewReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	returnRequest, err := rc.ReturnService.RejectReturn(idUint, userID.(uint), req.CatatanStaff)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Return request successfully rejected",
+		Data:    rc.convertToReturnResponse(returnRequest),
+	})
+}
+
+func (rc *ReturnController) ReceiveReturn(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.ReceiveReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	returnRequest, err := rc.ReturnService.ReceiveReturn(idUint, userID.(uint), req.InventoryID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Returned goods successfully received",
+		Data:    rc.convertToReturnResponse(returnRequest),
+	})
+}
+
+func (rc *ReturnController) CreateRefund(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.CreateRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	refund, err := rc.ReturnService.CreateRefund(idUint, userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Refund successfully created",
+		Data:    rc.convertToRefundResponse(refund),
+	})
+}
+
+func (rc *ReturnController) GetRefundsByOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	refunds, err := rc.ReturnService.GetRefundsByOrder(idUint)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.RefundResponse
+	for _, refund := range refunds {
+		responses = append(responses, rc.convertToRefundResponse(&refund))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Refunds successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (rc *ReturnController) convertToReturnResponse(returnRequest *models.ReturnRequest) models.ReturnResponse {
+	response := models.ReturnResponse{
+		ID:           returnRequest.ID,
+		OrderID:      returnRequest.OrderID,
+		OrderItemID:  returnRequest.OrderItemID,
+		ProductID:    returnRequest.OrderItem.ProductID,
+		UserID:       returnRequest.UserID,
+		Jumlah:       returnRequest.Jumlah,
+		Alasan:       returnRequest.Alasan,
+		Keterangan:   returnRequest.Keterangan,
+		Status:       returnRequest.Status,
+		CatatanStaff: returnRequest.CatatanStaff,
+		DiprosesOleh: returnRequest.DiprosesOleh,
+		InventoryID:  returnRequest.InventoryID,
+		CreatedAt:    returnRequest.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    returnRequest.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if returnRequest.DiterimaPada != nil {
+		response.DiterimaPada = returnRequest.DiterimaPada.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
+
+func (rc *ReturnController) convertToRefundResponse(refund *models.Refund) models.RefundResponse {
+	return models.RefundResponse{
+		ID:              refund.ID,
+		OrderID:         refund.OrderID,
+		ReturnRequestID: refund.ReturnRequestID,
+		Nominal:         refund.Nominal,
+		Alasan:          refund.Alasan,
+		DiprosesOleh:    refund.DiprosesOleh,
+		CreatedAt:       refund.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,250 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ShipmentController struct {
+	ShipmentService *services.ShipmentService
+}
+
+func NewShipmentController(db *gorm.DB) *ShipmentController {
+	return &ShipmentController{
+		ShipmentService: services.NewShipmentService(db),
+	}
+}
+
+func (sc *ShipmentController) CreateShipment(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.CreateShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	shipment, err := sc.ShipmentService.CreateShipment(idUint, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Shipment successfully created",
+		Data:    sc.convertToShipmentResponse(shipment),
+	})
+}
+
+func (sc *ShipmentController) GetShipmentsByOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	shipments, err := sc.ShipmentService.GetShipmentsByOrderAndUserID(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.ShipmentResponse
+	for _, shipment := range shipments {
+		responses = append(responses, sc.convertToShipmentResponse(&shipment))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Shipments successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (sc *ShipmentController) GetShipments(c *gin.Context) {
+	var req models.GetShipmentRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	shipments, err := sc.ShipmentService.GetShipmentsByOrder(req.OrderID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.ShipmentResponse
+	for _, shipment := range shipments {
+		responses = append(responses, sc.convertToShipmentResponse(&shipment))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Shipments successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (sc *ShipmentController) GetShipmentByID(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	shipment, err := sc.ShipmentService.GetShipmentByID(idUint)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Shipment successfully found",
+		Data:    sc.convertToShipmentResponse(shipment),
+	})
+}
+
+func (sc *ShipmentController) MarkDelivered(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	shipment, err := sc.ShipmentService.MarkDelivered(idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Shipment successfully marked as delivered",
+		Data:    sc.convertToShipmentResponse(shipment),
+	})
+}
+
+func (sc *ShipmentController) convertToShipmentResponse(shipment *models.Shipment) models.ShipmentResponse {
+	var items []models.ShipmentItemResponse
+	for _, item := range shipment.Items {
+		items = append(items, models.ShipmentItemResponse{
+			ID:          item.ID,
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.OrderItem.ProductID,
+			Jumlah:      item.Jumlah,
+		})
+	}
+
+	response := models.ShipmentResponse{
+		ID:          shipment.ID,
+		OrderID:     shipment.OrderID,
+		Kurir:       shipment.Kurir,
+		NomorResi:   shipment.NomorResi,
+		Lokasi:      shipment.Lokasi,
+		Status:      shipment.Status,
+		DikirimPada: shipment.DikirimPada.Format("2006-01-02 15:04:05"),
+		Items:       items,
+		CreatedAt:   shipment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   shipment.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if shipment.DiterimaPada != nil {
+		response.DiterimaPada = shipment.DiterimaPada.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SubscriptionController struct {
+	SubscriptionService *services.SubscriptionService
+}
+
+func NewSubscriptionController(db *gorm.DB) *SubscriptionController {
+	return &SubscriptionController{
+		SubscriptionService: services.NewSubscriptionService(db),
+	}
+}
+
+func (sc *SubscriptionController) CreateSubscription(c *gin.Context) {
+	var req models.CreateSubscriptionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	subscription, err := sc.SubscriptionService.CreateSubscription(userID.(uint), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Subscription successfully created",
+		Data:    sc.convertToSubscriptionResponse(subscription),
+	})
+}
+
+func (sc *SubscriptionController) GetSubscriptions(c *gin.Context) {
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	subscriptions, err := sc.SubscriptionService.GetSubscriptions(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.SubscriptionResponse
+	for _, subscription := range subscriptions {
+		responses = append(responses, sc.convertToSubscriptionResponse(&subscription))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Subscriptions successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (sc *SubscriptionController) GetSubscriptionByID(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	subscription, err := sc.SubscriptionService.GetSubscriptionByID(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Subscription successfully found",
+		Data:    sc.convertToSubscriptionResponse(subscription),
+	})
+}
+
+func (sc *SubscriptionController) GetSubscriptionRuns(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	runs, err := sc.SubscriptionService.GetSubscriptionRuns(idUint, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.SubscriptionRunResponse
+	for _, run := range runs {
+		responses = append(responses, models.SubscriptionRunResponse{
+			ID:         run.ID,
+			OrderID:    run.OrderID,
+			Status:     run.Status,
+			Keterangan: run.Keterangan,
+			JadwalPada: run.JadwalPada.Format("2006-01-02 15:04:05"),
+			CreatedAt:  run.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Subscription runs successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (sc *SubscriptionController) PauseSubscription(c *gin.Context) {
+	sc.changeStatus(c, sc.SubscriptionService.PauseSubscription, "Subscription successfully paused")
+}
+
+func (sc *SubscriptionController) ResumeSubscription(c *gin.Context) {
+	sc.changeStatus(c, sc.SubscriptionService.ResumeSubscription, "Subscription successfully resumed")
+}
+
+func (sc *SubscriptionController) CancelSubscription(c *gin.Context) {
+	sc.changeStatus(c, sc.SubscriptionService.CancelSubscription, "Subscription successfully cancelled")
+}
+
+func (sc *SubscriptionController) changeStatus(c *gin.Context, change func(id uint, userID uint) (*models.Subscription, error), message string) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "user not authenticated",
+		})
+		return
+	}
+
+	subscription, err := change(idUint, userID.(uint))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "subscription not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    sc.convertToSubscriptionResponse(subscription),
+	})
+}
+
+func (sc *SubscriptionController) convertToSubscriptionResponse(subscription *models.Subscription) models.SubscriptionResponse {
+	var items []models.SubscriptionItemResponse
+	for _, item := range subscription.Items {
+		items = append(items, models.SubscriptionItemResponse{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			Nama:      item.Product.Nama,
+			Jumlah:    item.Jumlah,
+		})
+	}
+
+	response := models.SubscriptionResponse{
+		ID:               subscription.ID,
+		UserID:           subscription.UserID,
+		AddressID:        subscription.AddressID,
+		MetodePengiriman: subscription.MetodePengiriman,
+		MataUang:         subscription.MataUang,
+		Jadwal:           subscription.Jadwal,
+		IntervalHari:     subscription.IntervalHari,
+		Status:           subscription.Status,
+		BerikutnyaPada:   subscription.BerikutnyaPada.Format("2006-01-02 15:04:05"),
+		GagalBeruntun:    subscription.GagalBeruntun,
+		PesanGagal:       subscription.PesanGagal,
+		Items:            items,
+		CreatedAt:        subscription.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:        subscription.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if subscription.TerakhirPada != nil {
+		response.TerakhirPada = subscription.TerakhirPada.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
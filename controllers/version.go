@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"fmt"
+	"golang-api/models"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestVersion returns the version an update is based on, taken from the If-Match header or,
+// when the header is absent, from the versi field of the request body. It writes the error
+// response itself and returns false when no usable version was sent.
+func requestVersion(c *gin.Context, versi uint) (uint, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" {
+		if versi == 0 {
+			c.JSON(http.StatusPreconditionRequired, models.APIResponse{
+				Success: false,
+				Message: "If-Match header or versi is required",
+			})
+			return 0, false
+		}
+		return versi, true
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+	parsed, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil || parsed == 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "If-Match header must be the ETag of the resource",
+		})
+		return 0, false
+	}
+
+	return uint(parsed), true
+}
+
+func setETag(c *gin.Context, versi uint) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, versi))
+}
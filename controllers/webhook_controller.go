@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang-api/models"
+	"golang-api/services"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WebhookController struct {
+	WebhookService *services.WebhookService
+}
+
+func NewWebhookController(db *gorm.DB) *WebhookController {
+	return &WebhookController{
+		WebhookService: services.NewWebhookService(db),
+	}
+}
+
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+	var req models.CreateWebhookRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	webhook, err := wc.WebhookService.CreateWebhook(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// The secret is only shown once, receivers need it to verify the X-Signature header.
+	response := wc.convertToWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Webhook successfully created",
+		Data:    response,
+	})
+}
+
+func (wc *WebhookController) GetWebhooks(c *gin.Context) {
+	webhooks, err := wc.WebhookService.GetWebhooks()
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.WebhookResponse
+	for _, webhook := range webhooks {
+		responses = append(responses, wc.convertToWebhookResponse(&webhook))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhooks successfully retrieved",
+		Data:    responses,
+	})
+}
+
+func (wc *WebhookController) GetWebhookByID(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	webhook, err := wc.WebhookService.GetWebhookByID(idUint)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook successfully found",
+		Data:    wc.convertToWebhookResponse(webhook),
+	})
+}
+
+func (wc *WebhookController) UpdateWebhook(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid data: " + err.Error(),
+		})
+		return
+	}
+
+	webhook, err := wc.WebhookService.UpdateWebhook(idUint, &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "webhook not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook successfully updated",
+		Data:    wc.convertToWebhookResponse(webhook),
+	})
+}
+
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	if err := wc.WebhookService.DeleteWebhook(idUint); err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "webhook not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook successfully deleted",
+	})
+}
+
+func (wc *WebhookController) GetWebhookDeliveries(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var req models.GetWebhookDeliveryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "invalid query parameters: " + err.Error(),
+		})
+		return
+	}
+
+	deliveries, total, err := wc.WebhookService.GetWebhookDeliveries(idUint, &req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var responses []models.WebhookDeliveryResponse
+	for _, delivery := range deliveries {
+		responses = append(responses, wc.convertToWebhookDeliveryResponse(&delivery))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook deliveries successfully retrieved",
+		Data:    responses,
+		Meta: &models.PaginationMeta{
+			Total:  total,
+			Limit:  req.Limit,
+			Offset: req.Offset,
+		},
+	})
+}
+
+func (wc *WebhookController) ReplayWebhookDelivery(c *gin.Context) {
+	idStr := c.Param("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID is required",
+		})
+		return
+	}
+
+	var idUint uint
+	_, err := fmt.Sscanf(idStr, "%d", &idUint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "ID must be a valid number",
+		})
+		return
+	}
+
+	var deliveryID uint
+	if _, err := fmt.Sscanf(c.Param("deliveryId"), "%d", &deliveryID); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "delivery ID must be a valid number",
+		})
+		return
+	}
+
+	delivery, err := wc.WebhookService.ReplayWebhookDelivery(idUint, deliveryID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "webhook delivery not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Webhook delivery successfully queued",
+		Data:    wc.convertToWebhookDeliveryResponse(delivery),
+	})
+}
+
+func (wc *WebhookController) convertToWebhookResponse(webhook *models.Webhook) models.WebhookResponse {
+	response := models.WebhookResponse{
+		ID:            webhook.ID,
+		URL:           webhook.URL,
+		Events:        strings.Split(webhook.Events, ","),
+		Aktif:         webhook.Aktif,
+		GagalBeruntun: webhook.GagalBeruntun,
+		CreatedAt:     webhook.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     webhook.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if webhook.DinonaktifkanPada != nil {
+		response.DinonaktifkanPada = webhook.DinonaktifkanPada.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
+
+func (wc *WebhookController) convertToWebhookDeliveryResponse(delivery *models.WebhookDelivery) models.WebhookDeliveryResponse {
+	response := models.WebhookDeliveryResponse{
+		ID:          delivery.ID,
+		WebhookID:   delivery.WebhookID,
+		EventID:     delivery.EventID,
+		Event:       delivery.Event,
+		Payload:     json.RawMessage(delivery.Payload),
+		Status:      delivery.Status,
+		Percobaan:   delivery.Percobaan,
+		KodeRespons: delivery.KodeRespons,
+		PesanGagal:  delivery.PesanGagal,
+		ReplayDari:  delivery.ReplayDari,
+		CreatedAt:   delivery.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if delivery.Status == "pending" {
+		response.BerikutnyaPada = delivery.BerikutnyaPada.Format("2006-01-02 15:04:05")
+	}
+
+	if delivery.TerkirimPada != nil {
+		response.TerkirimPada = delivery.TerkirimPada.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
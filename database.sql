@@ -7,6 +7,7 @@ CREATE TABLE users (
     name VARCHAR(255) NOT NULL,
     email VARCHAR(255) UNIQUE NOT NULL,
     password VARCHAR(255) NOT NULL,
+    role ENUM('customer', 'staff', 'admin', 'guest') DEFAULT 'customer',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL
@@ -16,10 +17,16 @@ CREATE TABLE users (
 CREATE TABLE products (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     nama VARCHAR(255) NOT NULL,
+    sku VARCHAR(64) NULL UNIQUE,
     deskripsi TEXT,
     harga DECIMAL(15,2) NOT NULL,
+    mata_uang CHAR(3) DEFAULT 'IDR',
     kategori VARCHAR(255) NOT NULL,
+    berat INT DEFAULT 0,
     foto_produk VARCHAR(255),
+    mode_stok ENUM('normal', 'backorder', 'preorder') DEFAULT 'normal',
+    tersedia_pada DATETIME(3) NULL,
+    versi INT UNSIGNED NOT NULL DEFAULT 1,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL
@@ -31,19 +38,62 @@ CREATE TABLE inventories (
     product_id BIGINT UNSIGNED NOT NULL,
     jumlah INT DEFAULT 0,
     lokasi VARCHAR(255) NOT NULL,
+    versi INT UNSIGNED NOT NULL DEFAULT 1,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL,
     FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
 );
 
+-- Membuat addresses tabel
+CREATE TABLE addresses (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    label VARCHAR(100),
+    nama_penerima VARCHAR(255) NOT NULL,
+    telepon VARCHAR(50) NOT NULL,
+    alamat TEXT NOT NULL,
+    kota VARCHAR(255) NOT NULL,
+    provinsi VARCHAR(255),
+    kode_pos VARCHAR(20),
+    is_default BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
 -- Membuat orders tabel
 CREATE TABLE orders (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    nomor_order VARCHAR(50) NULL UNIQUE,
     user_id BIGINT UNSIGNED NOT NULL,
+    mata_uang CHAR(3) DEFAULT 'IDR',
+    kurs DECIMAL(18,6) DEFAULT 1,
+    subtotal DECIMAL(15,2) DEFAULT 0,
+    total_pajak DECIMAL(15,2) DEFAULT 0,
+    harga_termasuk_pajak BOOLEAN DEFAULT FALSE,
+    address_id BIGINT UNSIGNED NULL,
+    pengiriman_nama_penerima VARCHAR(255),
+    pengiriman_telepon VARCHAR(50),
+    pengiriman_alamat TEXT,
+    pengiriman_kota VARCHAR(255),
+    pengiriman_provinsi VARCHAR(255),
+    pengiriman_kode_pos VARCHAR(20),
+    metode_pengiriman VARCHAR(50),
+    berat_total INT DEFAULT 0,
+    ongkos_kirim DECIMAL(15,2) DEFAULT 0,
     total_harga DECIMAL(15,2) DEFAULT 0,
-    status ENUM('pending', 'confirmed', 'shipped', 'delivered', 'cancelled') DEFAULT 'pending',
+    total_refund DECIMAL(15,2) DEFAULT 0,
+    total_bersih DECIMAL(15,2) DEFAULT 0,
+    status ENUM('pending', 'confirmed', 'partially_shipped', 'shipped', 'delivered', 'cancelled') DEFAULT 'pending',
     tanggal_order TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    dibatalkan_oleh BIGINT UNSIGNED NULL,
+    dibatalkan_pada TIMESTAMP NULL,
+    alasan_pembatalan VARCHAR(50),
+    komentar_pembatalan TEXT,
+    subscription_id BIGINT UNSIGNED NULL,
+    versi INT UNSIGNED NOT NULL DEFAULT 1,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL,
@@ -56,8 +106,14 @@ CREATE TABLE order_items (
     order_id BIGINT UNSIGNED NOT NULL,
     product_id BIGINT UNSIGNED NOT NULL,
     jumlah INT NOT NULL,
+    jumlah_dikirim INT DEFAULT 0,
+    jumlah_backorder INT DEFAULT 0,
+    jenis_backorder VARCHAR(20),
+    tersedia_pada DATETIME(3) NULL,
     harga DECIMAL(15,2) NOT NULL,
     subtotal DECIMAL(15,2) NOT NULL,
+    tarif_pajak DECIMAL(5,2) DEFAULT 0,
+    pajak DECIMAL(15,2) DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL,
@@ -65,6 +121,411 @@ CREATE TABLE order_items (
     FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
 );
 
+-- Membuat order_changes tabel
+CREATE TABLE order_changes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NULL,
+    aksi VARCHAR(50) NOT NULL,
+    order_item_id BIGINT UNSIGNED NULL,
+    product_id BIGINT UNSIGNED NULL,
+    jumlah_sebelum INT DEFAULT 0,
+    jumlah_sesudah INT DEFAULT 0,
+    total_sebelum DECIMAL(15,2) DEFAULT 0,
+    total_sesudah DECIMAL(15,2) DEFAULT 0,
+    keterangan TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- Membuat order_comments tabel (catatan order, internal hanya terlihat oleh staff)
+CREATE TABLE order_comments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    isi TEXT NOT NULL,
+    visibilitas ENUM('customer', 'internal') DEFAULT 'customer',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX (order_id),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Membuat payments tabel
+CREATE TABLE payments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL,
+    gateway VARCHAR(50) NOT NULL,
+    nominal DECIMAL(15,2) NOT NULL,
+    mata_uang CHAR(3) DEFAULT 'IDR',
+    status ENUM('pending', 'settled', 'failed', 'expired') DEFAULT 'pending',
+    referensi VARCHAR(100) UNIQUE,
+    payment_url VARCHAR(255),
+    dibayar_pada TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- Membuat return_requests tabel
+CREATE TABLE return_requests (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL,
+    order_item_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    jumlah INT NOT NULL,
+    alasan VARCHAR(50) NOT NULL,
+    keterangan TEXT,
+    status ENUM('requested', 'approved', 'rejected', 'received', 'refunded') DEFAULT 'requested',
+    catatan_staff TEXT,
+    diproses_oleh BIGINT UNSIGNED NULL,
+    inventory_id BIGINT UNSIGNED NULL,
+    diterima_pada TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE
+);
+
+-- Membuat refunds tabel
+CREATE TABLE refunds (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL,
+    return_request_id BIGINT UNSIGNED NULL,
+    nominal DECIMAL(15,2) NOT NULL,
+    alasan TEXT,
+    diproses_oleh BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (return_request_id) REFERENCES return_requests(id) ON DELETE SET NULL
+);
+
+-- Membuat shipments tabel
+CREATE TABLE shipments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL,
+    kurir VARCHAR(100) NOT NULL,
+    nomor_resi VARCHAR(100) NOT NULL,
+    lokasi VARCHAR(255) NOT NULL,
+    status ENUM('shipped', 'delivered') DEFAULT 'shipped',
+    dikirim_pada TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    diterima_pada TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- Membuat shipment_items tabel
+CREATE TABLE shipment_items (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    shipment_id BIGINT UNSIGNED NOT NULL,
+    order_item_id BIGINT UNSIGNED NOT NULL,
+    jumlah INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (shipment_id) REFERENCES shipments(id) ON DELETE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE
+);
+
+-- Membuat sequences tabel
+CREATE TABLE sequences (
+    nama VARCHAR(100) PRIMARY KEY,
+    nilai BIGINT UNSIGNED NOT NULL DEFAULT 0
+);
+
+-- Membuat invoices tabel
+CREATE TABLE invoices (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL UNIQUE,
+    nomor_invoice VARCHAR(50) NOT NULL UNIQUE,
+    tanggal_invoice TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    nomor_order VARCHAR(50),
+    tanggal_order TIMESTAMP NULL,
+    nama_pelanggan VARCHAR(255),
+    email_pelanggan VARCHAR(255),
+    pengiriman_nama_penerima VARCHAR(255),
+    pengiriman_telepon VARCHAR(50),
+    pengiriman_alamat TEXT,
+    pengiriman_kota VARCHAR(255),
+    pengiriman_provinsi VARCHAR(255),
+    pengiriman_kode_pos VARCHAR(20),
+    metode_pengiriman VARCHAR(50),
+    mata_uang CHAR(3),
+    subtotal DECIMAL(15,2) DEFAULT 0,
+    total_pajak DECIMAL(15,2) DEFAULT 0,
+    harga_termasuk_pajak BOOLEAN DEFAULT FALSE,
+    ongkos_kirim DECIMAL(15,2) DEFAULT 0,
+    total_harga DECIMAL(15,2) DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- Membuat invoice_items tabel (salinan baris order saat invoice diterbitkan)
+CREATE TABLE invoice_items (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT UNSIGNED NOT NULL,
+    nama_produk VARCHAR(255),
+    jumlah INT NOT NULL,
+    harga DECIMAL(15,2) DEFAULT 0,
+    pajak DECIMAL(15,2) DEFAULT 0,
+    subtotal DECIMAL(15,2) DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX (invoice_id),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
+
+-- Membuat exchange_rates tabel (nilai 1 unit mata uang dalam IDR)
+CREATE TABLE exchange_rates (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    mata_uang CHAR(3) NOT NULL UNIQUE,
+    kurs DECIMAL(18,6) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+-- Membuat order_exchange_rates tabel (kurs mata uang produk yang dikunci pada order)
+CREATE TABLE order_exchange_rates (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    order_id BIGINT UNSIGNED NOT NULL,
+    mata_uang CHAR(3) NOT NULL,
+    kurs DECIMAL(18,6) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    UNIQUE KEY idx_order_exchange_rate (order_id, mata_uang),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- Membuat archived_orders tabel (order delivered lama yang dipindahkan dari orders)
+CREATE TABLE archived_orders (
+    id BIGINT UNSIGNED PRIMARY KEY,
+    nomor_order VARCHAR(50),
+    user_id BIGINT UNSIGNED,
+    nama_pelanggan VARCHAR(255),
+    email_pelanggan VARCHAR(255),
+    mata_uang CHAR(3),
+    kurs DECIMAL(18,6),
+    subtotal DECIMAL(15,2),
+    total_pajak DECIMAL(15,2),
+    harga_termasuk_pajak BOOLEAN,
+    pengiriman_nama_penerima VARCHAR(255),
+    pengiriman_telepon VARCHAR(50),
+    pengiriman_alamat TEXT,
+    pengiriman_kota VARCHAR(255),
+    pengiriman_provinsi VARCHAR(255),
+    pengiriman_kode_pos VARCHAR(20),
+    metode_pengiriman VARCHAR(50),
+    berat_total INT,
+    ongkos_kirim DECIMAL(15,2),
+    total_harga DECIMAL(15,2),
+    total_refund DECIMAL(15,2),
+    total_bersih DECIMAL(15,2),
+    status VARCHAR(50),
+    nomor_invoice VARCHAR(50),
+    tanggal_invoice TIMESTAMP NULL,
+    referensi_pembayaran TEXT,
+    nomor_resi TEXT,
+    tanggal_order TIMESTAMP NULL,
+    dibuat_pada TIMESTAMP NULL,
+    diarsipkan_pada TIMESTAMP NULL,
+    INDEX (nomor_order),
+    INDEX (user_id),
+    INDEX (email_pelanggan),
+    INDEX (tanggal_order)
+);
+
+-- Membuat archived_order_items tabel
+CREATE TABLE archived_order_items (
+    id BIGINT UNSIGNED PRIMARY KEY,
+    archived_order_id BIGINT UNSIGNED NOT NULL,
+    product_id BIGINT UNSIGNED,
+    nama_produk VARCHAR(255),
+    jumlah INT,
+    harga DECIMAL(15,2),
+    subtotal DECIMAL(15,2),
+    tarif_pajak DECIMAL(5,2),
+    pajak DECIMAL(15,2),
+    INDEX (product_id),
+    FOREIGN KEY (archived_order_id) REFERENCES archived_orders(id) ON DELETE CASCADE
+);
+
+-- Membuat archived_refunds tabel (refund dari order yang diarsipkan)
+CREATE TABLE archived_refunds (
+    id BIGINT UNSIGNED PRIMARY KEY,
+    archived_order_id BIGINT UNSIGNED NOT NULL,
+    return_request_id BIGINT UNSIGNED NULL,
+    nominal DECIMAL(15,2),
+    alasan TEXT,
+    diproses_oleh BIGINT UNSIGNED,
+    dibuat_pada TIMESTAMP NULL,
+    FOREIGN KEY (archived_order_id) REFERENCES archived_orders(id) ON DELETE CASCADE
+);
+
+-- Membuat job_locks tabel (lease agar job terjadwal hanya berjalan di satu instance)
+CREATE TABLE job_locks (
+    nama VARCHAR(100) PRIMARY KEY,
+    pemilik VARCHAR(100),
+    berlaku_sampai DATETIME(3) NOT NULL
+);
+
+-- Membuat subscriptions tabel (order berulang mingguan, bulanan atau tiap N hari)
+CREATE TABLE subscriptions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    address_id BIGINT UNSIGNED NULL,
+    metode_pengiriman VARCHAR(50),
+    mata_uang CHAR(3) DEFAULT 'IDR',
+    jadwal ENUM('weekly', 'monthly', 'custom') NOT NULL,
+    interval_hari INT DEFAULT 0,
+    tanggal_jadwal INT DEFAULT 0,
+    status ENUM('active', 'paused', 'cancelled') DEFAULT 'active',
+    berikutnya_pada DATETIME(3) NOT NULL,
+    terakhir_pada DATETIME(3) NULL,
+    gagal_beruntun INT DEFAULT 0,
+    pesan_gagal TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX (status),
+    INDEX (berikutnya_pada),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Membuat subscription_items tabel
+CREATE TABLE subscription_items (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    subscription_id BIGINT UNSIGNED NOT NULL,
+    product_id BIGINT UNSIGNED NOT NULL,
+    jumlah INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+-- Membuat subscription_runs tabel (riwayat order yang dibuat atau gagal dibuat)
+CREATE TABLE subscription_runs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    subscription_id BIGINT UNSIGNED NOT NULL,
+    order_id BIGINT UNSIGNED NULL,
+    status ENUM('created', 'failed') NOT NULL,
+    keterangan TEXT,
+    jadwal_pada DATETIME(3),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions(id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL
+);
+
+-- Membuat webhooks tabel (endpoint yang menerima event order dan stok)
+CREATE TABLE webhooks (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    events VARCHAR(255) NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    aktif BOOLEAN DEFAULT TRUE,
+    gagal_beruntun INT DEFAULT 0,
+    dinonaktifkan_pada DATETIME(3) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX (aktif)
+);
+
+-- Membuat webhook_deliveries tabel (log pengiriman event, termasuk percobaan ulang dan replay)
+CREATE TABLE webhook_deliveries (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    webhook_id BIGINT UNSIGNED NOT NULL,
+    event_id CHAR(36) NOT NULL,
+    event VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    status ENUM('pending', 'delivered', 'failed') DEFAULT 'pending',
+    percobaan INT DEFAULT 0,
+    berikutnya_pada DATETIME(3),
+    kode_respons INT DEFAULT 0,
+    pesan_gagal TEXT,
+    terkirim_pada DATETIME(3) NULL,
+    replay_dari BIGINT UNSIGNED NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX (event_id),
+    INDEX (status, berikutnya_pada),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+-- Membuat notifications tabel (email ke customer tentang pesanan, dikirim oleh scheduler)
+CREATE TABLE notifications (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    order_id BIGINT UNSIGNED NULL,
+    jenis VARCHAR(64) NOT NULL,
+    bahasa CHAR(2) NOT NULL,
+    status_order VARCHAR(32),
+    status_sebelum VARCHAR(32),
+    penerima VARCHAR(255),
+    subjek VARCHAR(255),
+    isi TEXT,
+    status ENUM('pending', 'sending', 'sent', 'failed') DEFAULT 'pending',
+    percobaan INT DEFAULT 0,
+    pesan_gagal TEXT,
+    diklaim_pada DATETIME(3) NULL,
+    terkirim_pada DATETIME(3) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX (status),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL
+);
+
+-- Membuat notification_preferences tabel (bahasa dan jenis email yang diinginkan user)
+CREATE TABLE notification_preferences (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL UNIQUE,
+    bahasa CHAR(2) NOT NULL DEFAULT 'id',
+    email_order_baru BOOLEAN NOT NULL DEFAULT TRUE,
+    email_status_order BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Migrasi database lama yang menyimpan nominal sebagai DOUBLE (AutoMigrate juga melakukan ini saat start)
+ALTER TABLE products MODIFY harga DECIMAL(15,2) NOT NULL;
+ALTER TABLE orders
+    MODIFY subtotal DECIMAL(15,2) DEFAULT 0,
+    MODIFY total_pajak DECIMAL(15,2) DEFAULT 0,
+    MODIFY ongkos_kirim DECIMAL(15,2) DEFAULT 0,
+    MODIFY total_harga DECIMAL(15,2) DEFAULT 0,
+    MODIFY total_refund DECIMAL(15,2) DEFAULT 0,
+    MODIFY total_bersih DECIMAL(15,2) DEFAULT 0;
+ALTER TABLE order_items
+    MODIFY harga DECIMAL(15,2) NOT NULL,
+    MODIFY subtotal DECIMAL(15,2) NOT NULL,
+    MODIFY pajak DECIMAL(15,2) DEFAULT 0;
+
+-- Migrasi database lama tanpa role guest untuk guest checkout
+ALTER TABLE users MODIFY role ENUM('customer', 'staff', 'admin', 'guest') DEFAULT 'customer';
+
 -- Menambahkan 5 dummy users
 INSERT INTO users (name, email, password) VALUES
 ('Ahmad Rizki', 'ahmad.rizki@gmail.com', '$2a$10$hashedpassword1'),
@@ -90,21 +551,23 @@ INSERT INTO inventories (product_id, jumlah, lokasi) VALUES
 (5, 15, 'Gudang Yogyakarta');
 
 -- Menambahkan 5 dummy orders
-INSERT INTO orders (user_id, total_harga, status, tanggal_order) VALUES
-(1, 15000000.00, 'delivered', '2025-06-15 10:30:00'),
-(2, 12000000.00, 'shipped', '2025-06-20 14:15:00'),
-(3, 1500000.00, 'confirmed', '2025-06-22 09:45:00'),
-(4, 350000.00, 'pending', '2025-06-25 16:20:00'),
-(5, 2500000.00, 'delivered', '2025-06-18 11:10:00');
+INSERT INTO orders (user_id, total_harga, total_bersih, status, tanggal_order) VALUES
+(1, 15000000.00, 15000000.00, 'delivered', '2025-06-15 10:30:00'),
+(2, 12000000.00, 12000000.00, 'shipped', '2025-06-20 14:15:00'),
+(3, 1500000.00, 1500000.00, 'confirmed', '2025-06-22 09:45:00'),
+(4, 350000.00, 350000.00, 'pending', '2025-06-25 16:20:00'),
+(5, 2500000.00, 2500000.00, 'delivered', '2025-06-18 11:10:00');
+
 
+-- nomor_order untuk dummy orders diisi otomatis saat aplikasi dijalankan
 
 -- Menambahkan 5 dummy order items
-INSERT INTO order_items (order_id, product_id, jumlah, harga, subtotal) VALUES
-(1, 1, 1, 15000000.00, 15000000.00),
-(2, 2, 1, 12000000.00, 12000000.00),
-(3, 3, 1, 1500000.00, 1500000.00),
-(4, 4, 1, 350000.00, 350000.00),
-(5, 5, 1, 2500000.00, 2500000.00);
+INSERT INTO order_items (order_id, product_id, jumlah, jumlah_dikirim, harga, subtotal) VALUES
+(1, 1, 1, 1, 15000000.00, 15000000.00),
+(2, 2, 1, 1, 12000000.00, 12000000.00),
+(3, 3, 1, 0, 1500000.00, 1500000.00),
+(4, 4, 1, 0, 350000.00, 350000.00),
+(5, 5, 1, 1, 2500000.00, 2500000.00);
 
 -- Menampilkan semua produk beserta jumlah stok di tiap lokasi
 SELECT 
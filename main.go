@@ -4,6 +4,7 @@ import (
 	"golang-api/config"
 	"golang-api/models"
 	"golang-api/routes"
+	"golang-api/services"
 	"log"
 
 	"github.com/gin-gonic/gin"
@@ -26,6 +27,42 @@ func InitializeApp() *gin.Engine {
 	db.AutoMigrate(&models.Inventory{})
 	db.AutoMigrate(&models.Order{})
 	db.AutoMigrate(&models.OrderItem{})
+	db.AutoMigrate(&models.OrderChange{})
+	db.AutoMigrate(&models.Address{})
+	db.AutoMigrate(&models.Payment{})
+	db.AutoMigrate(&models.ReturnRequest{})
+	db.AutoMigrate(&models.Refund{})
+	db.AutoMigrate(&models.Shipment{})
+	db.AutoMigrate(&models.ShipmentItem{})
+	db.AutoMigrate(&models.Sequence{})
+	db.AutoMigrate(&models.Invoice{})
+	db.AutoMigrate(&models.InvoiceItem{})
+	db.AutoMigrate(&models.ExchangeRate{})
+	db.AutoMigrate(&models.OrderExchangeRate{})
+	db.AutoMigrate(&models.ArchivedOrder{})
+	db.AutoMigrate(&models.ArchivedOrderItem{})
+	db.AutoMigrate(&models.ArchivedRefund{})
+	db.AutoMigrate(&models.JobLock{})
+	db.AutoMigrate(&models.Subscription{})
+	db.AutoMigrate(&models.SubscriptionItem{})
+	db.AutoMigrate(&models.SubscriptionRun{})
+	db.AutoMigrate(&models.OrderComment{})
+	db.AutoMigrate(&models.Webhook{})
+	db.AutoMigrate(&models.WebhookDelivery{})
+	db.AutoMigrate(&models.Notification{})
+	db.AutoMigrate(&models.NotificationPreference{})
+
+	if err := services.BackfillOrderNumbers(db); err != nil {
+		log.Fatal("Error generating order numbers: " + err.Error())
+	}
+
+	if path := config.GetExchangeRateFile(); path != "" {
+		if err := services.ImportExchangeRateFile(db, path); err != nil {
+			log.Fatal("Error loading exchange rates: " + err.Error())
+		}
+	}
+
+	services.StartScheduler(db)
 
 	routes.SetupRoutes(r, db)
 
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"golang-api/models"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RoleMiddleware(db *gorm.DB, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, exists := c.Get("userId")
+		if !exists {
+			c.JSON(401, models.APIResponse{
+				Success: false,
+				Message: "user not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		var user models.User
+		if err := db.Select("id", "role").First(&user, userId.(uint)).Error; err != nil {
+			c.JSON(401, models.APIResponse{
+				Success: false,
+				Message: "user not found",
+			})
+			c.Abort()
+			return
+		}
+
+		if !slices.Contains(roles, user.Role) {
+			c.JSON(403, models.APIResponse{
+				Success: false,
+				Message: "you do not have permission to access this resource",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("userRole", user.Role)
+		c.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import "gorm.io/gorm"
+
+type Address struct {
+	gorm.Model
+	UserID       uint   `json:"user_id" gorm:"not null;index"`
+	User         User   `json:"user" gorm:"foreignKey:UserID"`
+	Label        string `json:"label"`
+	NamaPenerima string `json:"nama_penerima" gorm:"not null"`
+	Telepon      string `json:"telepon" gorm:"not null"`
+	Alamat       string `json:"alamat" gorm:"not null"`
+	Kota         string `json:"kota" gorm:"not null"`
+	Provinsi     string `json:"provinsi"`
+	KodePos      string `json:"kode_pos"`
+	IsDefault    bool   `json:"is_default" gorm:"default:false"`
+}
+
+type ShippingAddress struct {
+	NamaPenerima string `json:"nama_penerima"`
+	Telepon      string `json:"telepon"`
+	Alamat       string `json:"alamat"`
+	Kota         string `json:"kota"`
+	Provinsi     string `json:"provinsi"`
+	KodePos      string `json:"kode_pos"`
+}
+
+type AddAddressRequest struct {
+	Label        string `json:"label"`
+	NamaPenerima string `json:"nama_penerima" binding:"required"`
+	Telepon      string `json:"telepon" binding:"required"`
+	Alamat       string `json:"alamat" binding:"required"`
+	Kota         string `json:"kota" binding:"required"`
+	Provinsi     string `json:"provinsi"`
+	KodePos      string `json:"kode_pos"`
+	IsDefault    bool   `json:"is_default"`
+}
+
+type UpdateAddressRequest struct {
+	Label        string `json:"label"`
+	NamaPenerima string `json:"nama_penerima"`
+	Telepon      string `json:"telepon"`
+	Alamat       string `json:"alamat"`
+	Kota         string `json:"kota"`
+	Provinsi     string `json:"provinsi"`
+	KodePos      string `json:"kode_pos"`
+	IsDefault    bool   `json:"is_default"`
+}
+
+type AddressResponse struct {
+	ID           uint   `json:"id"`
+	UserID       uint   `json:"user_id"`
+	Label        string `json:"label"`
+	NamaPenerima string `json:"nama_penerima"`
+	Telepon      string `json:"telepon"`
+	Alamat       string `json:"alamat"`
+	Kota         string `json:"kota"`
+	Provinsi     string `json:"provinsi"`
+	KodePos      string `json:"kode_pos"`
+	IsDefault    bool   `json:"is_default"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+func (a *Address) ToShippingAddress() ShippingAddress {
+	return ShippingAddress{
+		NamaPenerima: a.NamaPenerima,
+		Telepon:      a.Telepon,
+		Alamat:       a.Alamat,
+		Kota:         a.Kota,
+		Provinsi:     a.Provinsi,
+		KodePos:      a.KodePos,
+	}
+}
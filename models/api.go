@@ -1,7 +1,14 @@
 package models
 
 type APIResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Data    any    `json:"data,omitempty"`
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    any             `json:"data,omitempty"`
+	Meta    *PaginationMeta `json:"meta,omitempty"`
+}
+
+type PaginationMeta struct {
+	Total  int64 `json:"total"`
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
 }
@@ -0,0 +1,127 @@
+package models
+
+import "time"
+
+// ArchivedOrder keeps the ID of the original order so references in logs and emails stay valid.
+type ArchivedOrder struct {
+	ID                  uint                `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	NomorOrder          string              `json:"nomor_order" gorm:"size:50;index"`
+	UserID              uint                `json:"user_id" gorm:"index"`
+	NamaPelanggan       string              `json:"nama_pelanggan"`
+	EmailPelanggan      string              `json:"email_pelanggan" gorm:"index"`
+	MataUang            string              `json:"mata_uang" gorm:"size:3"`
+	Kurs                float64             `json:"kurs" gorm:"type:decimal(18,6)"`
+	Subtotal            Money               `json:"subtotal"`
+	TotalPajak          Money               `json:"total_pajak"`
+	HargaTermasukPajak  bool                `json:"harga_termasuk_pajak"`
+	AlamatPengiriman    ShippingAddress     `json:"alamat_pengiriman" gorm:"embedded;embeddedPrefix:pengiriman_"`
+	MetodePengiriman    string              `json:"metode_pengiriman"`
+	BeratTotal          int                 `json:"berat_total"`
+	OngkosKirim         Money               `json:"ongkos_kirim"`
+	TotalHarga          Money               `json:"total_harga"`
+	TotalRefund         Money               `json:"total_refund"`
+	TotalBersih         Money               `json:"total_bersih"`
+	Status              string              `json:"status"`
+	NomorInvoice        string              `json:"nomor_invoice" gorm:"size:50"`
+	TanggalInvoice      *time.Time          `json:"tanggal_invoice"`
+	ReferensiPembayaran string              `json:"referensi_pembayaran"`
+	NomorResi           string              `json:"nomor_resi"`
+	TanggalOrder        time.Time           `json:"tanggal_order" gorm:"index"`
+	DibuatPada          time.Time           `json:"dibuat_pada"`
+	DiarsipkanPada      time.Time           `json:"diarsipkan_pada"`
+	Items               []ArchivedOrderItem `json:"items" gorm:"foreignKey:ArchivedOrderID"`
+	Refunds             []ArchivedRefund    `json:"refunds" gorm:"foreignKey:ArchivedOrderID"`
+}
+
+type ArchivedOrderItem struct {
+	ID              uint    `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	ArchivedOrderID uint    `json:"archived_order_id" gorm:"not null;index"`
+	ProductID       uint    `json:"product_id" gorm:"index"`
+	NamaProduk      string  `json:"nama_produk"`
+	Jumlah          int     `json:"jumlah"`
+	Harga           Money   `json:"harga"`
+	Subtotal        Money   `json:"subtotal"`
+	TarifPajak      float64 `json:"tarif_pajak"`
+	Pajak           Money   `json:"pajak"`
+}
+
+// ArchivedRefund keeps the ID of the original refund, refunds are accounting records.
+type ArchivedRefund struct {
+	ID              uint      `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	ArchivedOrderID uint      `json:"archived_order_id" gorm:"not null;index"`
+	ReturnRequestID *uint     `json:"return_request_id"`
+	Nominal         Money     `json:"nominal"`
+	Alasan          string    `json:"alasan"`
+	DiprosesOleh    uint      `json:"diproses_oleh"`
+	DibuatPada      time.Time `json:"dibuat_pada"`
+}
+
+type GetDeletedOrderRequest struct {
+	Limit  int `form:"limit,default=10" binding:"min=1,max=100"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
+
+type GetArchivedOrderRequest struct {
+	NomorOrder    string    `form:"nomor_order"`
+	UserID        uint      `form:"user_id"`
+	Email         string    `form:"email"`
+	TanggalDari   time.Time `form:"tanggal_dari" time_format:"2006-01-02"`
+	TanggalSampai time.Time `form:"tanggal_sampai" time_format:"2006-01-02"`
+	Limit         int       `form:"limit,default=10" binding:"min=1,max=100"`
+	Offset        int       `form:"offset,default=0" binding:"min=0"`
+}
+
+type OrderMaintenanceResponse struct {
+	Jumlah int    `json:"jumlah"`
+	Batas  string `json:"batas"`
+}
+
+type ArchivedOrderResponse struct {
+	ID                  uint                        `json:"id"`
+	NomorOrder          string                      `json:"nomor_order"`
+	UserID              uint                        `json:"user_id"`
+	NamaPelanggan       string                      `json:"nama_pelanggan"`
+	EmailPelanggan      string                      `json:"email_pelanggan"`
+	MataUang            string                      `json:"mata_uang"`
+	Kurs                float64                     `json:"kurs"`
+	Subtotal            Money                       `json:"subtotal"`
+	TotalPajak          Money                       `json:"total_pajak"`
+	HargaTermasukPajak  bool                        `json:"harga_termasuk_pajak"`
+	AlamatPengiriman    ShippingAddress             `json:"alamat_pengiriman"`
+	MetodePengiriman    string                      `json:"metode_pengiriman"`
+	BeratTotal          int                         `json:"berat_total"`
+	OngkosKirim         Money                       `json:"ongkos_kirim"`
+	TotalHarga          Money                       `json:"total_harga"`
+	TotalRefund         Money                       `json:"total_refund"`
+	TotalBersih         Money                       `json:"total_bersih"`
+	Status              string                      `json:"status"`
+	NomorInvoice        string                      `json:"nomor_invoice"`
+	TanggalInvoice      string                      `json:"tanggal_invoice,omitempty"`
+	ReferensiPembayaran string                      `json:"referensi_pembayaran"`
+	NomorResi           string                      `json:"nomor_resi"`
+	TanggalOrder        string                      `json:"tanggal_order"`
+	DibuatPada          string                      `json:"dibuat_pada"`
+	DiarsipkanPada      string                      `json:"diarsipkan_pada"`
+	Items               []ArchivedOrderItemResponse `json:"items"`
+	Refunds             []ArchivedRefundResponse    `json:"refunds"`
+}
+
+type ArchivedOrderItemResponse struct {
+	ID         uint    `json:"id"`
+	ProductID  uint    `json:"product_id"`
+	NamaProduk string  `json:"nama_produk"`
+	Jumlah     int     `json:"jumlah"`
+	Harga      Money   `json:"harga"`
+	Subtotal   Money   `json:"subtotal"`
+	TarifPajak float64 `json:"tarif_pajak"`
+	Pajak      Money   `json:"pajak"`
+}
+
+type ArchivedRefundResponse struct {
+	ID              uint   `json:"id"`
+	ReturnRequestID *uint  `json:"return_request_id,omitempty"`
+	Nominal         Money  `json:"nominal"`
+	Alasan          string `json:"alasan"`
+	DiprosesOleh    uint   `json:"diproses_oleh"`
+	DibuatPada      string `json:"dibuat_pada"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "gorm.io/gorm"
+
+// BaseCurrency is the currency of tax, shipping and reporting amounts.
+// Every exchange rate is expressed as the value of one unit of a currency in BaseCurrency.
+const BaseCurrency = "IDR"
+
+type ExchangeRate struct {
+	gorm.Model
+	MataUang string  `json:"mata_uang" gorm:"size:3;uniqueIndex;not null"`
+	Kurs     float64 `json:"kurs" gorm:"type:decimal(18,6);not null"`
+}
+
+// OrderExchangeRate locks the rate of a product currency on an order the first time the order needs
+// it, like Order.Kurs locks the rate of the order currency.
+type OrderExchangeRate struct {
+	gorm.Model
+	OrderID  uint    `json:"order_id" gorm:"not null;uniqueIndex:idx_order_exchange_rate"`
+	MataUang string  `json:"mata_uang" gorm:"size:3;not null;uniqueIndex:idx_order_exchange_rate"`
+	Kurs     float64 `json:"kurs" gorm:"type:decimal(18,6);not null"`
+}
+
+type UpdateExchangeRateRequest struct {
+	Kurs float64 `json:"kurs" binding:"required,gt=0"`
+}
+
+type ExchangeRateResponse struct {
+	ID        uint    `json:"id"`
+	MataUang  string  `json:"mata_uang"`
+	Kurs      float64 `json:"kurs"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
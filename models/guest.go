@@ -0,0 +1,40 @@
+package models
+
+// GuestCheckoutRequest places an order without an account. The contact details become a guest user,
+// so the order can be claimed later through the claim link sent when registering with the same email.
+type GuestCheckoutRequest struct {
+	Nama             string                   `json:"nama" binding:"required"`
+	Email            string                   `json:"email" binding:"required,email"`
+	Telepon          string                   `json:"telepon" binding:"required"`
+	Alamat           string                   `json:"alamat" binding:"required"`
+	Kota             string                   `json:"kota" binding:"required"`
+	Provinsi         string                   `json:"provinsi"`
+	KodePos          string                   `json:"kode_pos"`
+	Items            []CreateOrderItemRequest `json:"items" binding:"required,min=1"`
+	MetodePengiriman string                   `json:"metode_pengiriman"`
+	MataUang         string                   `json:"mata_uang"`
+}
+
+type GuestOrderLinkRequest struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+type GuestCheckoutResponse struct {
+	Order         OrderResponse `json:"order"`
+	LinkPelacakan string        `json:"link_pelacakan"`
+	BerlakuSampai string        `json:"berlaku_sampai"`
+}
+
+// GuestClaimLinkRequest holds the signed claim link that registering with the email of a guest sends
+// to that email, it proves that the new account owns the address.
+type GuestClaimLinkRequest struct {
+	Email     string `form:"email" binding:"required"`
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+type GuestClaimRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
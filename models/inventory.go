@@ -10,6 +10,7 @@ type Inventory struct {
 	Product   Product `json:"product" gorm:"foreignKey:ProductID"`
 	Jumlah    int     `json:"jumlah" gorm:"default:0"`
 	Lokasi    string  `json:"lokasi" gorm:"not null"`
+	Versi     uint    `json:"versi" gorm:"not null;default:1"`
 }
 
 type AddInventoryRequest struct {
@@ -21,6 +22,7 @@ type AddInventoryRequest struct {
 type UpdateInventoryRequest struct {
 	Jumlah int    `json:"jumlah" binding:"required,min=0"`
 	Lokasi string `json:"lokasi"`
+	Versi  uint   `json:"versi"`
 }
 
 type UpdateStockRequest struct {
@@ -35,6 +37,7 @@ type InventoryResponse struct {
 	Product   ProductResponse `json:"product"`
 	Jumlah    int             `json:"jumlah"`
 	Lokasi    string          `json:"lokasi"`
+	Versi     uint            `json:"versi"`
 	CreatedAt string          `json:"created_at"`
 	UpdatedAt string          `json:"updated_at"`
 }
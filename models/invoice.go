@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Invoice keeps a copy of the customer, address, lines and totals of the order at the moment it was
+// issued, so the invoice never changes when the order does afterwards.
+type Invoice struct {
+	gorm.Model
+	OrderID            uint            `json:"order_id" gorm:"not null;uniqueIndex"`
+	Order              Order           `json:"order" gorm:"foreignKey:OrderID"`
+	NomorInvoice       string          `json:"nomor_invoice" gorm:"size:50;not null;uniqueIndex"`
+	TanggalInvoice     time.Time       `json:"tanggal_invoice"`
+	NomorOrder         string          `json:"nomor_order" gorm:"size:50"`
+	TanggalOrder       time.Time       `json:"tanggal_order"`
+	NamaPelanggan      string          `json:"nama_pelanggan"`
+	EmailPelanggan     string          `json:"email_pelanggan"`
+	AlamatPengiriman   ShippingAddress `json:"alamat_pengiriman" gorm:"embedded;embeddedPrefix:pengiriman_"`
+	MetodePengiriman   string          `json:"metode_pengiriman"`
+	MataUang           string          `json:"mata_uang" gorm:"size:3"`
+	Subtotal           Money           `json:"subtotal"`
+	TotalPajak         Money           `json:"total_pajak"`
+	HargaTermasukPajak bool            `json:"harga_termasuk_pajak"`
+	OngkosKirim        Money           `json:"ongkos_kirim"`
+	TotalHarga         Money           `json:"total_harga"`
+	Items              []InvoiceItem   `json:"items"`
+}
+
+type InvoiceItem struct {
+	gorm.Model
+	InvoiceID  uint   `json:"invoice_id" gorm:"not null;index"`
+	NamaProduk string `json:"nama_produk"`
+	Jumlah     int    `json:"jumlah"`
+	Harga      Money  `json:"harga"`
+	Pajak      Money  `json:"pajak"`
+	Subtotal   Money  `json:"subtotal"`
+}
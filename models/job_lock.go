@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// JobLock is a lease that lets only one API instance run a scheduled job at a time.
+type JobLock struct {
+	Nama          string    `json:"nama" gorm:"primaryKey;size:100"`
+	Pemilik       string    `json:"pemilik" gorm:"size:100"`
+	BerlakuSampai time.Time `json:"berlaku_sampai" gorm:"not null"`
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Money is an amount in minor units (sen), stored as DECIMAL(15,2).
+// Every operation that can produce a fraction of a sen rounds half away from zero.
+type Money int64
+
+func NewMoneyFromFloat(value float64) Money {
+	return Money(math.Round(value * 100))
+}
+
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("empty money value")
+	}
+
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("invalid money value: %s", value)
+	}
+
+	rat.Mul(rat, big.NewRat(100, 1))
+
+	return Money(roundRat(rat)), nil
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+func (m Money) MulDiv(numerator, denominator int64) Money {
+	if denominator == 0 {
+		return 0
+	}
+
+	rat := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(numerator)),
+		big.NewInt(denominator),
+	)
+
+	return Money(roundRat(rat))
+}
+
+func (m Money) Percent(rate float64) Money {
+	return m.MulDiv(int64(math.Round(rate*100)), 10000)
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, value/100, value%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		*m = 0
+		return nil
+	}
+
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func (m *Money) UnmarshalParam(param string) error {
+	if param == "" {
+		*m = 0
+		return nil
+	}
+
+	parsed, err := ParseMoney(param)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		return m.UnmarshalParam(string(v))
+	case string:
+		return m.UnmarshalParam(v)
+	case int64:
+		*m = Money(v * 100)
+	case float64:
+		*m = NewMoneyFromFloat(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+
+	return nil
+}
+
+func (Money) GormDataType() string {
+	return "decimal(15,2)"
+}
+
+func roundRat(rat *big.Rat) int64 {
+	num := new(big.Int).Set(rat.Num())
+	den := rat.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	if negative {
+		quotient.Neg(quotient)
+	}
+
+	return quotient.Int64()
+}
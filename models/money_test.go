@@ -0,0 +1,122 @@
+package models
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "whole", value: "15000", want: 1500000},
+		{name: "cents", value: "12.34", want: 1234},
+		{name: "spaces", value: " 1.5 ", want: 150},
+		{name: "half cent rounds up", value: "0.005", want: 1},
+		{name: "below half cent rounds down", value: "0.0049", want: 0},
+		{name: "negative half cent rounds away from zero", value: "-0.005", want: -1},
+		{name: "empty", value: "", wantErr: true},
+		{name: "not a number", value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		value Money
+		want  string
+	}{
+		{value: 0, want: "0.00"},
+		{value: 5, want: "0.05"},
+		{value: 1234, want: "12.34"},
+		{value: -1234, want: "-12.34"},
+		{value: -5, want: "-0.05"},
+		{value: 1500000, want: "15000.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.value), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMulDiv(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       Money
+		numerator   int64
+		denominator int64
+		want        Money
+	}{
+		{name: "exact", value: 1000, numerator: 1, denominator: 4, want: 250},
+		{name: "half rounds up", value: 1, numerator: 1, denominator: 2, want: 1},
+		{name: "third rounds down", value: 100, numerator: 1, denominator: 3, want: 33},
+		{name: "two thirds rounds up", value: 100, numerator: 2, denominator: 3, want: 67},
+		{name: "negative half rounds away from zero", value: -1, numerator: 1, denominator: 2, want: -1},
+		{name: "zero denominator", value: 1000, numerator: 1, denominator: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.MulDiv(tt.numerator, tt.denominator); got != tt.want {
+				t.Errorf("Money(%d).MulDiv(%d, %d) = %d, want %d", int64(tt.value), tt.numerator, tt.denominator, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyPercent(t *testing.T) {
+	tests := []struct {
+		value Money
+		rate  float64
+		want  Money
+	}{
+		{value: 10000, rate: 11, want: 1100},
+		{value: 999, rate: 11, want: 110},
+		{value: 12345, rate: 2.5, want: 309},
+		{value: 10000, rate: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.Percent(tt.rate); got != tt.want {
+			t.Errorf("Money(%d).Percent(%v) = %d, want %d", int64(tt.value), tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Money
+		wantErr bool
+	}{
+		{data: `12.5`, want: 1250},
+		{data: `"12.5"`, want: 1250},
+		{data: `null`, want: 0},
+		{data: `""`, want: 0},
+		{data: `"abc"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := got.UnmarshalJSON([]byte(tt.data))
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("UnmarshalJSON(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %d, want %d", tt.data, got, tt.want)
+		}
+	}
+}
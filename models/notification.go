@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Notification is an email to a customer about an order event. Only the event is stored when the
+// order changes; the scheduler renders the email and fills Penerima, Subjek and Isi when sending it.
+type Notification struct {
+	gorm.Model
+	UserID        uint       `json:"user_id" gorm:"not null;index"`
+	OrderID       *uint      `json:"order_id" gorm:"index"`
+	Jenis         string     `json:"jenis" gorm:"size:64;not null"`
+	Bahasa        string     `json:"bahasa" gorm:"size:2;not null"`
+	StatusOrder   string     `json:"status_order" gorm:"size:32"`
+	StatusSebelum string     `json:"status_sebelum" gorm:"size:32"`
+	Penerima      string     `json:"penerima"`
+	Subjek        string     `json:"subjek"`
+	Isi           string     `json:"isi" gorm:"type:text"`
+	Status        string     `json:"status" gorm:"default:pending;index"`
+	Percobaan     int        `json:"percobaan" gorm:"default:0"`
+	PesanGagal    string     `json:"pesan_gagal"`
+	DiklaimPada   *time.Time `json:"diklaim_pada"`
+	TerkirimPada  *time.Time `json:"terkirim_pada"`
+}
+
+type NotificationPreference struct {
+	gorm.Model
+	UserID           uint   `json:"user_id" gorm:"not null;uniqueIndex"`
+	Bahasa           string `json:"bahasa" gorm:"size:2;not null"`
+	EmailOrderBaru   bool   `json:"email_order_baru" gorm:"not null"`
+	EmailStatusOrder bool   `json:"email_status_order" gorm:"not null"`
+}
+
+type UpdateNotificationPreferenceRequest struct {
+	Bahasa           string `json:"bahasa" binding:"omitempty,oneof=id en"`
+	EmailOrderBaru   *bool  `json:"email_order_baru"`
+	EmailStatusOrder *bool  `json:"email_status_order"`
+}
+
+type GetNotificationRequest struct {
+	Limit  int `form:"limit,default=10"`
+	Offset int `form:"offset,default=0"`
+}
+
+type NotificationPreferenceResponse struct {
+	Bahasa           string `json:"bahasa"`
+	EmailOrderBaru   bool   `json:"email_order_baru"`
+	EmailStatusOrder bool   `json:"email_status_order"`
+}
+
+type NotificationResponse struct {
+	ID           uint   `json:"id"`
+	OrderID      *uint  `json:"order_id,omitempty"`
+	Jenis        string `json:"jenis"`
+	Penerima     string `json:"penerima"`
+	Subjek       string `json:"subjek"`
+	Isi          string `json:"isi"`
+	Status       string `json:"status"`
+	TerkirimPada string `json:"terkirim_pada,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
@@ -8,27 +8,59 @@ import (
 
 type Order struct {
 	gorm.Model
-	UserID       uint        `json:"user_id" gorm:"not null"`
-	User         User        `json:"user" gorm:"foreignKey:UserID"`
-	TotalHarga   float64     `json:"total_harga" gorm:"default:0"`
-	Status       string      `json:"status" gorm:"default:pending"`
-	TanggalOrder time.Time   `json:"tanggal_order"`
-	OrderItems   []OrderItem `json:"order_items" gorm:"foreignKey:OrderID"`
+	NomorOrder         string          `json:"nomor_order" gorm:"size:50;uniqueIndex"`
+	UserID             uint            `json:"user_id" gorm:"not null"`
+	User               User            `json:"user" gorm:"foreignKey:UserID"`
+	SubscriptionID     *uint           `json:"subscription_id" gorm:"index"`
+	MataUang           string          `json:"mata_uang" gorm:"size:3;default:IDR"`
+	Kurs               float64         `json:"kurs" gorm:"type:decimal(18,6);default:1"`
+	Subtotal           Money           `json:"subtotal" gorm:"default:0"`
+	TotalPajak         Money           `json:"total_pajak" gorm:"default:0"`
+	HargaTermasukPajak bool            `json:"harga_termasuk_pajak" gorm:"default:false"`
+	AddressID          *uint           `json:"address_id"`
+	AlamatPengiriman   ShippingAddress `json:"alamat_pengiriman" gorm:"embedded;embeddedPrefix:pengiriman_"`
+	MetodePengiriman   string          `json:"metode_pengiriman"`
+	BeratTotal         int             `json:"berat_total" gorm:"default:0"`
+	OngkosKirim        Money           `json:"ongkos_kirim" gorm:"default:0"`
+	TotalHarga         Money           `json:"total_harga" gorm:"default:0"`
+	TotalRefund        Money           `json:"total_refund" gorm:"default:0"`
+	TotalBersih        Money           `json:"total_bersih" gorm:"default:0"`
+	Status             string          `json:"status" gorm:"default:pending"`
+	TanggalOrder       time.Time       `json:"tanggal_order"`
+	DibatalkanOleh     *uint           `json:"dibatalkan_oleh"`
+	DibatalkanPada     *time.Time      `json:"dibatalkan_pada"`
+	AlasanPembatalan   string          `json:"alasan_pembatalan"`
+	KomentarPembatalan string          `json:"komentar_pembatalan"`
+	Versi              uint            `json:"versi" gorm:"not null;default:1"`
+	OrderItems         []OrderItem     `json:"order_items" gorm:"foreignKey:OrderID"`
 }
 
 type OrderItem struct {
 	gorm.Model
-	OrderID   uint    `json:"order_id" gorm:"not null"`
-	Order     Order   `json:"order" gorm:"foreignKey:OrderID"`
-	ProductID uint    `json:"product_id" gorm:"not null"`
-	Product   Product `json:"product" gorm:"foreignKey:ProductID"`
-	Jumlah    int     `json:"jumlah" gorm:"not null"`
-	Harga     float64 `json:"harga" gorm:"not null"`
-	Subtotal  float64 `json:"subtotal" gorm:"not null"`
+	OrderID       uint    `json:"order_id" gorm:"not null"`
+	Order         Order   `json:"order" gorm:"foreignKey:OrderID"`
+	ProductID     uint    `json:"product_id" gorm:"not null"`
+	Product       Product `json:"product" gorm:"foreignKey:ProductID"`
+	Jumlah        int     `json:"jumlah" gorm:"not null"`
+	JumlahDikirim int     `json:"jumlah_dikirim" gorm:"default:0"`
+	// JumlahBackorder is the part of Jumlah that is not covered by stock yet.
+	JumlahBackorder int        `json:"jumlah_backorder" gorm:"default:0"`
+	JenisBackorder  string     `json:"jenis_backorder"`
+	TersediaPada    *time.Time `json:"tersedia_pada"`
+	Harga           Money      `json:"harga" gorm:"not null"`
+	Subtotal        Money      `json:"subtotal" gorm:"not null"`
+	TarifPajak      float64    `json:"tarif_pajak" gorm:"default:0"`
+	Pajak           Money      `json:"pajak" gorm:"default:0"`
 }
 
 type CreateOrderRequest struct {
-	Items []CreateOrderItemRequest `json:"items" binding:"required,min=1"`
+	Items            []CreateOrderItemRequest `json:"items" binding:"required,min=1"`
+	AddressID        uint                     `json:"address_id"`
+	MetodePengiriman string                   `json:"metode_pengiriman"`
+	MataUang         string                   `json:"mata_uang"`
+	// AlamatPengiriman ships the order to an address that is not in the address book, as guest
+	// checkout does. It cannot be set through the API.
+	AlamatPengiriman *ShippingAddress `json:"-"`
 }
 
 type CreateOrderItemRequest struct {
@@ -37,25 +69,51 @@ type CreateOrderItemRequest struct {
 }
 
 type OrderResponse struct {
-	ID           uint                `json:"id"`
-	UserID       uint                `json:"user_id"`
-	User         UserResponse        `json:"user"`
-	TotalHarga   float64             `json:"total_harga"`
-	Status       string              `json:"status"`
-	TanggalOrder string              `json:"tanggal_order"`
-	OrderItems   []OrderItemResponse `json:"order_items"`
-	CreatedAt    string              `json:"created_at"`
-	UpdatedAt    string              `json:"updated_at"`
+	ID                 uint                   `json:"id"`
+	NomorOrder         string                 `json:"nomor_order"`
+	UserID             uint                   `json:"user_id"`
+	User               UserResponse           `json:"user"`
+	SubscriptionID     *uint                  `json:"subscription_id,omitempty"`
+	MataUang           string                 `json:"mata_uang"`
+	Kurs               float64                `json:"kurs"`
+	Subtotal           Money                  `json:"subtotal"`
+	TotalPajak         Money                  `json:"total_pajak"`
+	HargaTermasukPajak bool                   `json:"harga_termasuk_pajak"`
+	AlamatPengiriman   ShippingAddress        `json:"alamat_pengiriman"`
+	MetodePengiriman   string                 `json:"metode_pengiriman"`
+	BeratTotal         int                    `json:"berat_total"`
+	OngkosKirim        Money                  `json:"ongkos_kirim"`
+	TotalHarga         Money                  `json:"total_harga"`
+	TotalRefund        Money                  `json:"total_refund"`
+	TotalBersih        Money                  `json:"total_bersih"`
+	Status             string                 `json:"status"`
+	Versi              uint                   `json:"versi"`
+	TanggalOrder       string                 `json:"tanggal_order"`
+	DibatalkanOleh     *uint                  `json:"dibatalkan_oleh,omitempty"`
+	DibatalkanPada     string                 `json:"dibatalkan_pada,omitempty"`
+	AlasanPembatalan   string                 `json:"alasan_pembatalan,omitempty"`
+	KomentarPembatalan string                 `json:"komentar_pembatalan,omitempty"`
+	DihapusPada        string                 `json:"dihapus_pada,omitempty"`
+	OrderItems         []OrderItemResponse    `json:"order_items"`
+	Komentar           []OrderCommentResponse `json:"komentar,omitempty"`
+	CreatedAt          string                 `json:"created_at"`
+	UpdatedAt          string                 `json:"updated_at"`
 }
 
 type OrderItemResponse struct {
-	ID        uint            `json:"id"`
-	OrderID   uint            `json:"order_id"`
-	ProductID uint            `json:"product_id"`
-	Product   ProductResponse `json:"product"`
-	Jumlah    int             `json:"jumlah"`
-	Harga     float64         `json:"harga"`
-	Subtotal  float64         `json:"subtotal"`
+	ID              uint            `json:"id"`
+	OrderID         uint            `json:"order_id"`
+	ProductID       uint            `json:"product_id"`
+	Product         ProductResponse `json:"product"`
+	Jumlah          int             `json:"jumlah"`
+	JumlahDikirim   int             `json:"jumlah_dikirim"`
+	JumlahBackorder int             `json:"jumlah_backorder"`
+	JenisBackorder  string          `json:"jenis_backorder,omitempty"`
+	TersediaPada    string          `json:"tersedia_pada,omitempty"`
+	Harga           Money           `json:"harga"`
+	Subtotal        Money           `json:"subtotal"`
+	TarifPajak      float64         `json:"tarif_pajak"`
+	Pajak           Money           `json:"pajak"`
 }
 
 type UserResponse struct {
@@ -64,13 +122,59 @@ type UserResponse struct {
 	Email string `json:"email"`
 }
 
+// OrderFilter holds the order filters shared by the order list and the order export.
+type OrderFilter struct {
+	Status        []string  `form:"status"`
+	NomorOrder    string    `form:"nomor_order"`
+	UserID        uint      `form:"user_id"`
+	Email         string    `form:"email"`
+	ProductID     uint      `form:"product_id"`
+	TanggalDari   time.Time `form:"tanggal_dari" time_format:"2006-01-02"`
+	TanggalSampai time.Time `form:"tanggal_sampai" time_format:"2006-01-02"`
+	TotalMin      Money     `form:"total_min"`
+	TotalMax      Money     `form:"total_max"`
+}
+
 type GetOrderRequest struct {
-	Status string `form:"status"`
-	UserID uint   `form:"user_id"`
-	Limit  int    `form:"limit,default=10"`
-	Offset int    `form:"offset,default=0"`
+	OrderFilter
+	SortBy    string `form:"sort_by,default=created_at" binding:"oneof=created_at tanggal_order total_harga nomor_order status"`
+	SortOrder string `form:"sort_order,default=desc" binding:"oneof=asc desc"`
+	Limit     int    `form:"limit,default=10" binding:"min=1,max=100"`
+	Offset    int    `form:"offset,default=0" binding:"min=0"`
+}
+
+type ExportOrderRequest struct {
+	OrderFilter
+	Format    string `form:"format,default=csv" binding:"oneof=csv xlsx"`
+	SortBy    string `form:"sort_by,default=created_at" binding:"oneof=created_at tanggal_order total_harga nomor_order status"`
+	SortOrder string `form:"sort_order,default=desc" binding:"oneof=asc desc"`
+}
+
+type CancelOrderRequest struct {
+	Alasan   string `json:"alasan" binding:"required,oneof=changed_mind found_cheaper ordered_by_mistake delivery_too_long payment_issue other"`
+	Komentar string `json:"komentar" binding:"required,max=500"`
 }
 
+// UpdateOrderStatusRequest lets staff cancel an order. Other statuses follow from payments and
+// shipments and cannot be set by hand.
 type UpdateOrderStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=pending confirmed shipped delivered cancelled"`
+	Status   string `json:"status" binding:"required,oneof=cancelled"`
+	Komentar string `json:"komentar" binding:"max=500"`
+	Versi    uint   `json:"versi"`
+}
+
+// ReorderAdjustment describes a line of a reorder that differs from the original order.
+type ReorderAdjustment struct {
+	ProductID     uint   `json:"product_id"`
+	Nama          string `json:"nama"`
+	Alasan        string `json:"alasan"`
+	JumlahSebelum int    `json:"jumlah_sebelum"`
+	JumlahSesudah int    `json:"jumlah_sesudah"`
+	HargaSebelum  Money  `json:"harga_sebelum,omitempty"`
+	HargaSesudah  Money  `json:"harga_sesudah,omitempty"`
+}
+
+type ReorderResponse struct {
+	Order       OrderResponse       `json:"order"`
+	Penyesuaian []ReorderAdjustment `json:"penyesuaian"`
 }
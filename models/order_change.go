@@ -0,0 +1,41 @@
+package models
+
+import "gorm.io/gorm"
+
+type OrderChange struct {
+	gorm.Model
+	OrderID       uint   `json:"order_id" gorm:"not null;index"`
+	UserID        *uint  `json:"user_id"`
+	Aksi          string `json:"aksi" gorm:"not null"`
+	OrderItemID   *uint  `json:"order_item_id"`
+	ProductID     *uint  `json:"product_id"`
+	JumlahSebelum int    `json:"jumlah_sebelum"`
+	JumlahSesudah int    `json:"jumlah_sesudah"`
+	TotalSebelum  Money  `json:"total_sebelum"`
+	TotalSesudah  Money  `json:"total_sesudah"`
+	Keterangan    string `json:"keterangan"`
+}
+
+type AddOrderItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	Jumlah    int  `json:"jumlah" binding:"required,min=1"`
+}
+
+type UpdateOrderItemRequest struct {
+	Jumlah int `json:"jumlah" binding:"required,min=1"`
+}
+
+type OrderChangeResponse struct {
+	ID            uint   `json:"id"`
+	OrderID       uint   `json:"order_id"`
+	UserID        *uint  `json:"user_id"`
+	Aksi          string `json:"aksi"`
+	OrderItemID   *uint  `json:"order_item_id"`
+	ProductID     *uint  `json:"product_id"`
+	JumlahSebelum int    `json:"jumlah_sebelum"`
+	JumlahSesudah int    `json:"jumlah_sesudah"`
+	TotalSebelum  Money  `json:"total_sebelum"`
+	TotalSesudah  Money  `json:"total_sesudah"`
+	Keterangan    string `json:"keterangan"`
+	CreatedAt     string `json:"created_at"`
+}
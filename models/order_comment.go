@@ -0,0 +1,31 @@
+package models
+
+import "gorm.io/gorm"
+
+// OrderComment is a note in the comment thread of an order. Internal comments are only
+// visible to staff.
+type OrderComment struct {
+	gorm.Model
+	OrderID     uint   `json:"order_id" gorm:"not null;index"`
+	UserID      uint   `json:"user_id" gorm:"not null"`
+	User        User   `json:"user" gorm:"foreignKey:UserID"`
+	Isi         string `json:"isi" gorm:"type:text;not null"`
+	Visibilitas string `json:"visibilitas" gorm:"default:customer"`
+}
+
+type AddOrderCommentRequest struct {
+	Isi         string `json:"isi" binding:"required,max=2000"`
+	Visibilitas string `json:"visibilitas" binding:"omitempty,oneof=customer internal"`
+}
+
+type OrderCommentResponse struct {
+	ID           uint   `json:"id"`
+	OrderID      uint   `json:"order_id"`
+	UserID       uint   `json:"user_id"`
+	NamaPenulis  string `json:"nama_penulis"`
+	PeranPenulis string `json:"peran_penulis"`
+	Isi          string `json:"isi"`
+	Visibilitas  string `json:"visibilitas"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
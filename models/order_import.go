@@ -0,0 +1,32 @@
+package models
+
+type ImportOrderRequest struct {
+	DryRun bool   `form:"dry_run"`
+	Mode   string `form:"mode,default=all_or_nothing" binding:"oneof=all_or_nothing per_order"`
+}
+
+type ImportOrderRowError struct {
+	Baris int    `json:"baris"`
+	Pesan string `json:"pesan"`
+}
+
+// ImportedOrder is an order of the import file. OrderID and NomorOrder are only set when the
+// order was actually created.
+type ImportedOrder struct {
+	Email      string `json:"email"`
+	Referensi  string `json:"referensi,omitempty"`
+	Baris      []int  `json:"baris"`
+	OrderID    uint   `json:"order_id,omitempty"`
+	NomorOrder string `json:"nomor_order,omitempty"`
+	MataUang   string `json:"mata_uang"`
+	TotalHarga Money  `json:"total_harga"`
+}
+
+type ImportOrderResult struct {
+	DryRun      bool                  `json:"dry_run"`
+	Mode        string                `json:"mode"`
+	TotalBaris  int                   `json:"total_baris"`
+	OrderDibuat int                   `json:"order_dibuat"`
+	Orders      []ImportedOrder       `json:"orders"`
+	Errors      []ImportOrderRowError `json:"errors"`
+}
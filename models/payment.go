@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Payment struct {
+	gorm.Model
+	OrderID     uint       `json:"order_id" gorm:"not null;index"`
+	Order       Order      `json:"order" gorm:"foreignKey:OrderID"`
+	Gateway     string     `json:"gateway" gorm:"not null"`
+	Nominal     Money      `json:"nominal" gorm:"not null"`
+	MataUang    string     `json:"mata_uang" gorm:"size:3;default:IDR"`
+	Status      string     `json:"status" gorm:"default:pending"`
+	Referensi   string     `json:"referensi" gorm:"size:100;uniqueIndex"`
+	PaymentURL  string     `json:"payment_url"`
+	DibayarPada *time.Time `json:"dibayar_pada"`
+}
+
+type CreatePaymentRequest struct {
+	Gateway string `json:"gateway" binding:"required"`
+}
+
+type PaymentNotification struct {
+	Referensi string `json:"referensi" binding:"required"`
+	Status    string `json:"status" binding:"required,oneof=settled failed expired"`
+	Nominal   Money  `json:"nominal" binding:"required"`
+}
+
+type SimulatePaymentRequest struct {
+	Status string `json:"status" binding:"omitempty,oneof=settled failed expired"`
+}
+
+type PaymentResponse struct {
+	ID          uint   `json:"id"`
+	OrderID     uint   `json:"order_id"`
+	Gateway     string `json:"gateway"`
+	Nominal     Money  `json:"nominal"`
+	MataUang    string `json:"mata_uang"`
+	Status      string `json:"status"`
+	Referensi   string `json:"referensi"`
+	PaymentURL  string `json:"payment_url"`
+	DibayarPada string `json:"dibayar_pada,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
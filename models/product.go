@@ -9,39 +9,67 @@ import (
 type Product struct {
 	gorm.Model
 	Nama       string  `json:"nama"`
+	SKU        *string `json:"sku" gorm:"size:64;uniqueIndex"`
 	Deskripsi  string  `json:"deskripsi"`
-	Harga      float64 `json:"harga"`
+	Harga      Money   `json:"harga"`
+	MataUang   string  `json:"mata_uang" gorm:"size:3;default:IDR"`
 	Kategori   string  `json:"kategori"`
+	Berat      int     `json:"berat" gorm:"default:0"`
 	FotoProduk string  `json:"foto_produk"`
+	// ModeStok decides what happens when the product is out of stock: normal refuses the order,
+	// backorder and preorder accept it and the missing quantity waits for incoming stock.
+	ModeStok     string     `json:"mode_stok" gorm:"default:normal"`
+	TersediaPada *time.Time `json:"tersedia_pada"`
+	Versi        uint       `json:"versi" gorm:"not null;default:1"`
 }
 
 type AddProductRequest struct {
-	Nama      string  `form:"nama" binding:"required"`
-	Deskripsi string  `form:"deskripsi"`
-	Harga     float64 `form:"harga" binding:"required"`
-	Kategori  string  `form:"kategori" binding:"required"`
+	Nama         string    `form:"nama" binding:"required"`
+	SKU          string    `form:"sku" binding:"max=64"`
+	Deskripsi    string    `form:"deskripsi"`
+	Harga        Money     `form:"harga" binding:"required"`
+	MataUang     string    `form:"mata_uang"`
+	Kategori     string    `form:"kategori" binding:"required"`
+	Berat        int       `form:"berat" binding:"min=0"`
+	ModeStok     string    `form:"mode_stok" binding:"omitempty,oneof=normal backorder preorder"`
+	TersediaPada time.Time `form:"tersedia_pada" time_format:"2006-01-02"`
 }
 
 type ProductResponse struct {
-	ID         uint      `json:"id"`
-	Nama       string    `json:"nama"`
-	Deskripsi  string    `json:"deskripsi"`
-	Harga      float64   `json:"harga"`
-	Kategori   string    `json:"kategori"`
-	FotoProduk string    `json:"foto_produk"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID               uint       `json:"id"`
+	Nama             string     `json:"nama"`
+	SKU              *string    `json:"sku,omitempty"`
+	Deskripsi        string     `json:"deskripsi"`
+	Harga            Money      `json:"harga"`
+	MataUang         string     `json:"mata_uang"`
+	HargaKonversi    *Money     `json:"harga_konversi,omitempty"`
+	MataUangKonversi string     `json:"mata_uang_konversi,omitempty"`
+	Kategori         string     `json:"kategori"`
+	Berat            int        `json:"berat"`
+	FotoProduk       string     `json:"foto_produk"`
+	ModeStok         string     `json:"mode_stok"`
+	TersediaPada     *time.Time `json:"tersedia_pada,omitempty"`
+	Versi            uint       `json:"versi"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 type GetProductRequest struct {
 	Kategori string `form:"kategori"`
+	Currency string `form:"currency"`
 	Limit    int    `form:"limit,default=10"`
 	Offset   int    `form:"offset,default=0"`
 }
 
 type UpdateProductRequest struct {
-	Nama      string  `form:"nama"`
-	Deskripsi string  `form:"deskripsi"`
-	Harga     float64 `form:"harga"`
-	Kategori  string  `form:"kategori"`
+	Nama         string    `form:"nama"`
+	SKU          string    `form:"sku" binding:"max=64"`
+	Deskripsi    string    `form:"deskripsi"`
+	Harga        Money     `form:"harga"`
+	MataUang     string    `form:"mata_uang"`
+	Kategori     string    `form:"kategori"`
+	Berat        int       `form:"berat" binding:"min=0"`
+	ModeStok     string    `form:"mode_stok" binding:"omitempty,oneof=normal backorder preorder"`
+	TersediaPada time.Time `form:"tersedia_pada" time_format:"2006-01-02"`
+	Versi        uint      `form:"versi"`
 }
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReturnRequest struct {
+	gorm.Model
+	OrderID      uint       `json:"order_id" gorm:"not null;index"`
+	Order        Order      `json:"order" gorm:"foreignKey:OrderID"`
+	OrderItemID  uint       `json:"order_item_id" gorm:"not null;index"`
+	OrderItem    OrderItem  `json:"order_item" gorm:"foreignKey:OrderItemID"`
+	UserID       uint       `json:"user_id" gorm:"not null"`
+	Jumlah       int        `json:"jumlah" gorm:"not null"`
+	Alasan       string     `json:"alasan" gorm:"not null"`
+	Keterangan   string     `json:"keterangan"`
+	Status       string     `json:"status" gorm:"default:requested"`
+	CatatanStaff string     `json:"catatan_staff"`
+	DiprosesOleh *uint      `json:"diproses_oleh"`
+	InventoryID  *uint      `json:"inventory_id"`
+	DiterimaPada *time.Time `json:"diterima_pada"`
+}
+
+type Refund struct {
+	gorm.Model
+	OrderID         uint   `json:"order_id" gorm:"not null;index"`
+	Order           Order  `json:"order" gorm:"foreignKey:OrderID"`
+	ReturnRequestID *uint  `json:"return_request_id"`
+	Nominal         Money  `json:"nominal" gorm:"not null"`
+	Alasan          string `json:"alasan"`
+	DiprosesOleh    uint   `json:"diproses_oleh" gorm:"not null"`
+}
+
+type CreateReturnRequest struct {
+	OrderItemID uint   `json:"order_item_id" binding:"required"`
+	Jumlah      int    `json:"jumlah" binding:"required,min=1"`
+	Alasan      string `json:"alasan" binding:"required,oneof=damaged wrong_item not_as_described no_longer_needed other"`
+	Keterangan  string `json:"keterangan"`
+}
+
+type ReviewReturnRequest struct {
+	CatatanStaff string `json:"catatan_staff"`
+}
+
+type ReceiveReturnRequest struct {
+	InventoryID uint `json:"inventory_id" binding:"required"`
+}
+
+type CreateRefundRequest struct {
+	ReturnRequestID uint   `json:"return_request_id"`
+	Nominal         Money  `json:"nominal" binding:"min=0"`
+	Penuh           bool   `json:"penuh"`
+	Alasan          string `json:"alasan"`
+}
+
+type GetReturnRequest struct {
+	Status  string `form:"status"`
+	OrderID uint   `form:"order_id"`
+	Limit   int    `form:"limit,default=10"`
+	Offset  int    `form:"offset,default=0"`
+}
+
+type ReturnResponse struct {
+	ID           uint   `json:"id"`
+	OrderID      uint   `json:"order_id"`
+	OrderItemID  uint   `json:"order_item_id"`
+	ProductID    uint   `json:"product_id"`
+	UserID       uint   `json:"user_id"`
+	Jumlah       int    `json:"jumlah"`
+	Alasan       string `json:"alasan"`
+	Keterangan   string `json:"keterangan"`
+	Status       string `json:"status"`
+	CatatanStaff string `json:"catatan_staff"`
+	DiprosesOleh *uint  `json:"diproses_oleh"`
+	InventoryID  *uint  `json:"inventory_id"`
+	DiterimaPada string `json:"diterima_pada,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type RefundResponse struct {
+	ID              uint   `json:"id"`
+	OrderID         uint   `json:"order_id"`
+	ReturnRequestID *uint  `json:"return_request_id"`
+	Nominal         Money  `json:"nominal"`
+	Alasan          string `json:"alasan"`
+	DiprosesOleh    uint   `json:"diproses_oleh"`
+	CreatedAt       string `json:"created_at"`
+}
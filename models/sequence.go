@@ -0,0 +1,6 @@
+package models
+
+type Sequence struct {
+	Nama  string `json:"nama" gorm:"primaryKey;size:100"`
+	Nilai uint64 `json:"nilai" gorm:"not null;default:0"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Shipment struct {
+	gorm.Model
+	OrderID      uint           `json:"order_id" gorm:"not null;index"`
+	Order        Order          `json:"order" gorm:"foreignKey:OrderID"`
+	Kurir        string         `json:"kurir" gorm:"not null"`
+	NomorResi    string         `json:"nomor_resi" gorm:"not null"`
+	Lokasi       string         `json:"lokasi" gorm:"not null"`
+	Status       string         `json:"status" gorm:"default:shipped"`
+	DikirimPada  time.Time      `json:"dikirim_pada"`
+	DiterimaPada *time.Time     `json:"diterima_pada"`
+	Items        []ShipmentItem `json:"items" gorm:"foreignKey:ShipmentID"`
+}
+
+type ShipmentItem struct {
+	gorm.Model
+	ShipmentID  uint      `json:"shipment_id" gorm:"not null;index"`
+	OrderItemID uint      `json:"order_item_id" gorm:"not null;index"`
+	OrderItem   OrderItem `json:"order_item" gorm:"foreignKey:OrderItemID"`
+	Jumlah      int       `json:"jumlah" gorm:"not null"`
+}
+
+type CreateShipmentRequest struct {
+	Kurir     string                      `json:"kurir" binding:"required"`
+	NomorResi string                      `json:"nomor_resi" binding:"required"`
+	Lokasi    string                      `json:"lokasi" binding:"required"`
+	Items     []CreateShipmentItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type CreateShipmentItemRequest struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Jumlah      int  `json:"jumlah" binding:"required,min=1"`
+}
+
+type GetShipmentRequest struct {
+	OrderID uint `form:"order_id" binding:"required"`
+}
+
+type ShipmentResponse struct {
+	ID           uint                   `json:"id"`
+	OrderID      uint                   `json:"order_id"`
+	Kurir        string                 `json:"kurir"`
+	NomorResi    string                 `json:"nomor_resi"`
+	Lokasi       string                 `json:"lokasi"`
+	Status       string                 `json:"status"`
+	DikirimPada  string                 `json:"dikirim_pada"`
+	DiterimaPada string                 `json:"diterima_pada,omitempty"`
+	Items        []ShipmentItemResponse `json:"items"`
+	CreatedAt    string                 `json:"created_at"`
+	UpdatedAt    string                 `json:"updated_at"`
+}
+
+type ShipmentItemResponse struct {
+	ID          uint `json:"id"`
+	OrderItemID uint `json:"order_item_id"`
+	ProductID   uint `json:"product_id"`
+	Jumlah      int  `json:"jumlah"`
+}
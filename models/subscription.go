@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Subscription struct {
+	gorm.Model
+	UserID           uint               `json:"user_id" gorm:"not null;index"`
+	User             User               `json:"user" gorm:"foreignKey:UserID"`
+	AddressID        uint               `json:"address_id"`
+	MetodePengiriman string             `json:"metode_pengiriman"`
+	MataUang         string             `json:"mata_uang" gorm:"size:3;default:IDR"`
+	Jadwal           string             `json:"jadwal" gorm:"not null"`
+	IntervalHari     int                `json:"interval_hari" gorm:"default:0"`
+	TanggalJadwal    int                `json:"tanggal_jadwal" gorm:"default:0"`
+	Status           string             `json:"status" gorm:"default:active;index"`
+	BerikutnyaPada   time.Time          `json:"berikutnya_pada" gorm:"index"`
+	TerakhirPada     *time.Time         `json:"terakhir_pada"`
+	GagalBeruntun    int                `json:"gagal_beruntun" gorm:"default:0"`
+	PesanGagal       string             `json:"pesan_gagal"`
+	Items            []SubscriptionItem `json:"items" gorm:"foreignKey:SubscriptionID"`
+}
+
+type SubscriptionItem struct {
+	gorm.Model
+	SubscriptionID uint    `json:"subscription_id" gorm:"not null;index"`
+	ProductID      uint    `json:"product_id" gorm:"not null"`
+	Product        Product `json:"product" gorm:"foreignKey:ProductID"`
+	Jumlah         int     `json:"jumlah" gorm:"not null"`
+}
+
+type SubscriptionRun struct {
+	gorm.Model
+	SubscriptionID uint      `json:"subscription_id" gorm:"not null;index"`
+	OrderID        *uint     `json:"order_id"`
+	Status         string    `json:"status" gorm:"not null"`
+	Keterangan     string    `json:"keterangan"`
+	JadwalPada     time.Time `json:"jadwal_pada"`
+}
+
+type CreateSubscriptionRequest struct {
+	Items            []CreateOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	AddressID        uint                     `json:"address_id"`
+	MetodePengiriman string                   `json:"metode_pengiriman"`
+	MataUang         string                   `json:"mata_uang"`
+	Jadwal           string                   `json:"jadwal" binding:"required,oneof=weekly monthly custom"`
+	IntervalHari     int                      `json:"interval_hari" binding:"required_if=Jadwal custom,min=0,max=365"`
+	MulaiPada        string                   `json:"mulai_pada" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type SubscriptionResponse struct {
+	ID               uint                       `json:"id"`
+	UserID           uint                       `json:"user_id"`
+	AddressID        uint                       `json:"address_id"`
+	MetodePengiriman string                     `json:"metode_pengiriman"`
+	MataUang         string                     `json:"mata_uang"`
+	Jadwal           string                     `json:"jadwal"`
+	IntervalHari     int                        `json:"interval_hari,omitempty"`
+	Status           string                     `json:"status"`
+	BerikutnyaPada   string                     `json:"berikutnya_pada"`
+	TerakhirPada     string                     `json:"terakhir_pada,omitempty"`
+	GagalBeruntun    int                        `json:"gagal_beruntun"`
+	PesanGagal       string                     `json:"pesan_gagal,omitempty"`
+	Items            []SubscriptionItemResponse `json:"items"`
+	CreatedAt        string                     `json:"created_at"`
+	UpdatedAt        string                     `json:"updated_at"`
+}
+
+type SubscriptionItemResponse struct {
+	ID        uint   `json:"id"`
+	ProductID uint   `json:"product_id"`
+	Nama      string `json:"nama"`
+	Jumlah    int    `json:"jumlah"`
+}
+
+type SubscriptionRunResponse struct {
+	ID         uint   `json:"id"`
+	OrderID    *uint  `json:"order_id"`
+	Status     string `json:"status"`
+	Keterangan string `json:"keterangan,omitempty"`
+	JadwalPada string `json:"jadwal_pada"`
+	CreatedAt  string `json:"created_at"`
+}
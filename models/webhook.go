@@ -0,0 +1,115 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	WebhookEventOrderCreated       = "order.created"
+	WebhookEventOrderStatusChanged = "order.status_changed"
+	WebhookEventStockChanged       = "inventory.stock_changed"
+)
+
+type Webhook struct {
+	gorm.Model
+	URL string `json:"url" gorm:"size:2048;not null"`
+	// Events is the comma separated list of event types the endpoint receives.
+	Events            string     `json:"events" gorm:"size:255;not null"`
+	Secret            string     `json:"-" gorm:"size:128;not null"`
+	Aktif             bool       `json:"aktif" gorm:"default:true;index"`
+	GagalBeruntun     int        `json:"gagal_beruntun" gorm:"default:0"`
+	DinonaktifkanPada *time.Time `json:"dinonaktifkan_pada"`
+}
+
+type WebhookDelivery struct {
+	gorm.Model
+	WebhookID uint `json:"webhook_id" gorm:"not null;index"`
+	// EventID is shared by all deliveries of the same event, including replays, so receivers can deduplicate.
+	EventID        string     `json:"event_id" gorm:"size:36;not null;index"`
+	Event          string     `json:"event" gorm:"size:64;not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"default:pending;index"`
+	Percobaan      int        `json:"percobaan" gorm:"default:0"`
+	BerikutnyaPada time.Time  `json:"berikutnya_pada" gorm:"index"`
+	KodeRespons    int        `json:"kode_respons" gorm:"default:0"`
+	PesanGagal     string     `json:"pesan_gagal"`
+	TerkirimPada   *time.Time `json:"terkirim_pada"`
+	ReplayDari     *uint      `json:"replay_dari"`
+}
+
+// WebhookEvent is the JSON body posted to webhook endpoints.
+type WebhookEvent struct {
+	ID         string `json:"id"`
+	Event      string `json:"event"`
+	DibuatPada string `json:"dibuat_pada"`
+	Data       any    `json:"data"`
+}
+
+type WebhookOrderData struct {
+	ID            uint   `json:"id"`
+	NomorOrder    string `json:"nomor_order"`
+	UserID        uint   `json:"user_id"`
+	Status        string `json:"status"`
+	StatusSebelum string `json:"status_sebelum,omitempty"`
+	MataUang      string `json:"mata_uang"`
+	TotalHarga    Money  `json:"total_harga"`
+}
+
+type WebhookStockData struct {
+	InventoryID   uint    `json:"inventory_id"`
+	ProductID     uint    `json:"product_id"`
+	SKU           *string `json:"sku,omitempty"`
+	Lokasi        string  `json:"lokasi"`
+	JumlahSebelum int     `json:"jumlah_sebelum"`
+	Jumlah        int     `json:"jumlah"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=2048"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=order.created order.status_changed inventory.stock_changed"`
+	Secret string   `json:"secret" binding:"omitempty,min=16,max=128"`
+}
+
+type UpdateWebhookRequest struct {
+	URL    string   `json:"url" binding:"omitempty,url,max=2048"`
+	Events []string `json:"events" binding:"omitempty,min=1,dive,oneof=order.created order.status_changed inventory.stock_changed"`
+	Aktif  *bool    `json:"aktif"`
+}
+
+type GetWebhookDeliveryRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending delivered failed"`
+	Event  string `form:"event"`
+	Limit  int    `form:"limit,default=10"`
+	Offset int    `form:"offset,default=0"`
+}
+
+type WebhookResponse struct {
+	ID                uint     `json:"id"`
+	URL               string   `json:"url"`
+	Events            []string `json:"events"`
+	Secret            string   `json:"secret,omitempty"`
+	Aktif             bool     `json:"aktif"`
+	GagalBeruntun     int      `json:"gagal_beruntun"`
+	DinonaktifkanPada string   `json:"dinonaktifkan_pada,omitempty"`
+	CreatedAt         string   `json:"created_at"`
+	UpdatedAt         string   `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uint            `json:"id"`
+	WebhookID      uint            `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Percobaan      int             `json:"percobaan"`
+	BerikutnyaPada string          `json:"berikutnya_pada,omitempty"`
+	KodeRespons    int             `json:"kode_respons,omitempty"`
+	PesanGagal     string          `json:"pesan_gagal,omitempty"`
+	TerkirimPada   string          `json:"terkirim_pada,omitempty"`
+	ReplayDari     *uint           `json:"replay_dari,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+}
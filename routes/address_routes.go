@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupAddressRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	addressController := controllers.NewAddressController(db)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.POST("/addresses", addressController.CreateAddress)
+		protected.GET("/addresses", addressController.GetAddresses)
+		protected.GET("/addresses/:id", addressController.GetAddressByID)
+		protected.PUT("/addresses/:id", addressController.UpdateAddress)
+		protected.DELETE("/addresses/:id", addressController.DeleteAddress)
+	}
+}
@@ -14,6 +14,7 @@ func SetupAuthRoutes(router *gin.RouterGroup, db *gorm.DB) {
 	{
 		protected.POST("/register", authController.Register)
 		protected.POST("/login", authController.Login)
+		protected.POST("/guest/claim", authController.ClaimGuest)
 	}
 
 }
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupCurrencyRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	currencyController := controllers.NewCurrencyController(db)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.GET("/exchange-rates", currencyController.GetExchangeRates)
+	}
+
+	admin := router.Group("/")
+	admin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(db, "admin"))
+	{
+		admin.PUT("/exchange-rates/:currency", currencyController.SetExchangeRate)
+		admin.POST("/exchange-rates/import", currencyController.ImportExchangeRates)
+		admin.DELETE("/exchange-rates/:currency", currencyController.DeleteExchangeRate)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupInvoiceRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	invoiceController := controllers.NewInvoiceController(db)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.GET("/orders/:id/invoice.pdf", invoiceController.DownloadInvoice)
+	}
+}
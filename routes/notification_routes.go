@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupNotificationRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	notificationController := controllers.NewNotificationController(db)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.GET("/notifications", notificationController.GetNotifications)
+		protected.GET("/notification-preferences", notificationController.GetNotificationPreferences)
+		protected.PUT("/notification-preferences", notificationController.UpdateNotificationPreferences)
+	}
+}
@@ -11,13 +11,51 @@ import (
 func SetupOrderRoutes(router *gin.RouterGroup, db *gorm.DB) {
 	orderController := controllers.NewOrderController(db)
 
+	router.POST("/guest/orders", orderController.GuestCheckout)
+	router.GET("/guest/orders/:nomor", orderController.GetGuestOrder)
+
 	protected := router.Group("/")
 	protected.Use(middleware.AuthMiddleware())
 	{
 		protected.POST("/orders", orderController.CreateOrder)
 		protected.GET("/orders", orderController.GetOrders)
+		protected.GET("/orders/export", orderController.ExportOrders)
 		protected.GET("/orders/:id", orderController.GetOrderByID)
-		protected.PUT("/orders/:id/status", orderController.UpdateOrderStatus)
-		protected.DELETE("/orders/:id", orderController.DeleteOrder)
+		protected.POST("/orders/:id/cancel", orderController.CancelOrder)
+		protected.POST("/orders/:id/reorder", orderController.Reorder)
+
+		protected.POST("/orders/:id/items", orderController.AddOrderItem)
+		protected.PUT("/orders/:id/items/:itemId", orderController.UpdateOrderItem)
+		protected.DELETE("/orders/:id/items/:itemId", orderController.RemoveOrderItem)
+		protected.GET("/orders/:id/changes", orderController.GetOrderChanges)
+
+		protected.GET("/orders/:id/comments", orderController.GetOrderComments)
+		protected.POST("/orders/:id/comments", orderController.AddOrderComment)
+	}
+
+	staff := router.Group("/")
+	staff.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(db, "admin", "staff"))
+	{
+		staff.PUT("/orders/:id/status", orderController.UpdateOrderStatus)
+		staff.DELETE("/orders/:id", orderController.DeleteOrder)
+		staff.GET("/staff/orders", orderController.GetAllOrders)
+		staff.GET("/staff/orders/export", orderController.ExportAllOrders)
+		staff.POST("/staff/orders/import", orderController.ImportOrders)
+		staff.GET("/staff/orders/:id", orderController.GetAnyOrderByID)
+		staff.GET("/staff/orders/:id/comments", orderController.GetStaffOrderComments)
+		staff.POST("/staff/orders/:id/comments", orderController.AddStaffOrderComment)
+
+		staff.GET("/staff/deleted-orders", orderController.GetDeletedOrders)
+		staff.POST("/staff/deleted-orders/:id/restore", orderController.RestoreOrder)
+
+		staff.GET("/staff/archived-orders", orderController.GetArchivedOrders)
+		staff.GET("/staff/archived-orders/:id", orderController.GetArchivedOrderByID)
+	}
+
+	admin := router.Group("/")
+	admin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(db, "admin"))
+	{
+		admin.POST("/staff/deleted-orders/purge", orderController.PurgeDeletedOrders)
+		admin.POST("/staff/archived-orders/run", orderController.ArchiveOrders)
 	}
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"golang-api/config"
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupPaymentRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	paymentController := controllers.NewPaymentController(db)
+
+	router.POST("/payments/webhook/:gateway", paymentController.HandleWebhook)
+	router.POST("/guest/orders/:nomor/payments", paymentController.CreateGuestPayment)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.POST("/orders/:id/payments", paymentController.CreatePayment)
+		protected.GET("/orders/:id/payments", paymentController.GetPaymentsByOrder)
+
+		if config.IsMockPaymentEnabled() {
+			protected.POST("/payments/mock/:reference/settle", paymentController.SimulateMockPayment)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupReturnRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	returnController := controllers.NewReturnController(db)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.POST("/orders/:id/returns", returnController.CreateReturn)
+		protected.GET("/orders/:id/returns", returnController.GetReturnsByOrder)
+	}
+
+	staff := router.Group("/")
+	staff.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(db, "admin", "staff"))
+	{
+		staff.GET("/returns", returnController.GetReturns)
+		staff.GET("/returns/:id", returnController.GetReturnByID)
+		staff.PUT("/returns/:id/approve", returnController.ApproveReturn)
+		staff.PUT("/returns/:id/reject", returnController.RejectReturn)
+		staff.PUT("/returns/:id/receive", returnController.ReceiveReturn)
+
+		staff.POST("/orders/:id/refunds", returnController.CreateRefund)
+		staff.GET("/orders/:id/refunds", returnController.GetRefundsByOrder)
+	}
+}
@@ -15,5 +15,23 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB) {
 		SetupInventoryRoutes(api, db)
 
 		SetupOrderRoutes(api, db)
+
+		SetupAddressRoutes(api, db)
+
+		SetupPaymentRoutes(api, db)
+
+		SetupReturnRoutes(api, db)
+
+		SetupShipmentRoutes(api, db)
+
+		SetupInvoiceRoutes(api, db)
+
+		SetupCurrencyRoutes(api, db)
+
+		SetupSubscriptionRoutes(api, db)
+
+		SetupWebhookRoutes(api, db)
+
+		SetupNotificationRoutes(api, db)
 	}
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupShipmentRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	shipmentController := controllers.NewShipmentController(db)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.GET("/orders/:id/shipments", shipmentController.GetShipmentsByOrder)
+	}
+
+	staff := router.Group("/")
+	staff.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(db, "admin", "staff"))
+	{
+		staff.POST("/orders/:id/shipments", shipmentController.CreateShipment)
+		staff.GET("/shipments", shipmentController.GetShipments)
+		staff.GET("/shipments/:id", shipmentController.GetShipmentByID)
+		staff.PUT("/shipments/:id/deliver", shipmentController.MarkDelivered)
+	}
+}
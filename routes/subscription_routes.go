@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupSubscriptionRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	subscriptionController := controllers.NewSubscriptionController(db)
+
+	protected := router.Group("/")
+	protected.Use(middleware.AuthMiddleware())
+	{
+		protected.POST("/subscriptions", subscriptionController.CreateSubscription)
+		protected.GET("/subscriptions", subscriptionController.GetSubscriptions)
+		protected.GET("/subscriptions/:id", subscriptionController.GetSubscriptionByID)
+		protected.GET("/subscriptions/:id/runs", subscriptionController.GetSubscriptionRuns)
+		protected.PUT("/subscriptions/:id/pause", subscriptionController.PauseSubscription)
+		protected.PUT("/subscriptions/:id/resume", subscriptionController.ResumeSubscription)
+		protected.PUT("/subscriptions/:id/cancel", subscriptionController.CancelSubscription)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"golang-api/controllers"
+	"golang-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func SetupWebhookRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	webhookController := controllers.NewWebhookController(db)
+
+	admin := router.Group("/")
+	admin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(db, "admin"))
+	{
+		admin.POST("/webhooks", webhookController.CreateWebhook)
+		admin.GET("/webhooks", webhookController.GetWebhooks)
+		admin.GET("/webhooks/:id", webhookController.GetWebhookByID)
+		admin.PUT("/webhooks/:id", webhookController.UpdateWebhook)
+		admin.DELETE("/webhooks/:id", webhookController.DeleteWebhook)
+		admin.GET("/webhooks/:id/deliveries", webhookController.GetWebhookDeliveries)
+		admin.POST("/webhooks/:id/deliveries/:deliveryId/replay", webhookController.ReplayWebhookDelivery)
+	}
+}
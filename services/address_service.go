@@ -0,0 +1,145 @@
+package services
+
+import (
+	"errors"
+	"golang-api/models"
+
+	"gorm.io/gorm"
+)
+
+type AddressService struct {
+	DB *gorm.DB
+}
+
+func NewAddressService(db *gorm.DB) *AddressService {
+	return &AddressService{DB: db}
+}
+
+func (as *AddressService) CreateAddress(address *models.Address) (*models.Address, error) {
+	tx := as.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var count int64
+	if err := tx.Model(&models.Address{}).Where("user_id = ?", address.UserID).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if count == 0 {
+		address.IsDefault = true
+	}
+
+	if address.IsDefault {
+		if err := as.clearDefault(tx, address.UserID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Create(address).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error creating address: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return address, nil
+}
+
+func (as *AddressService) GetAddresses(userID uint) ([]models.Address, error) {
+	var addresses []models.Address
+
+	err := as.DB.Where("user_id = ?", userID).Order("is_default DESC, created_at DESC").Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		return nil, errors.New("no addresses found")
+	}
+
+	return addresses, nil
+}
+
+func (as *AddressService) GetAddressByIDAndUserID(id uint, userID uint) (*models.Address, error) {
+	var address models.Address
+
+	err := as.DB.Where("id = ? AND user_id = ?", id, userID).First(&address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("address not found")
+		}
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+func (as *AddressService) GetDefaultAddress(userID uint) (*models.Address, error) {
+	var address models.Address
+
+	err := as.DB.Where("user_id = ?", userID).Order("is_default DESC, created_at DESC").First(&address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("shipping address is required")
+		}
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+func (as *AddressService) UpdateAddress(address *models.Address) (*models.Address, error) {
+	tx := as.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if address.IsDefault {
+		if err := as.clearDefault(tx, address.UserID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Save(address).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating address: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return address, nil
+}
+
+func (as *AddressService) DeleteAddress(id uint, userID uint) error {
+	address, err := as.GetAddressByIDAndUserID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := as.DB.Delete(address).Error; err != nil {
+		return errors.New("error deleting address: " + err.Error())
+	}
+
+	return nil
+}
+
+func (as *AddressService) clearDefault(tx *gorm.DB, userID uint) error {
+	err := tx.Model(&models.Address{}).Where("user_id = ? AND is_default = ?", userID, true).Update("is_default", false).Error
+	if err != nil {
+		return errors.New("error updating default address: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"golang-api/utils"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const guestClaimNotification = "guest_claim"
+
+// ClaimGuest turns the guest with the email of a signed claim link into a customer account, which
+// then owns the orders and addresses of the guest.
+func (as *AuthService) ClaimGuest(link *models.GuestClaimLinkRequest, req *models.GuestClaimRequest) (string, error) {
+	email := strings.ToLower(strings.TrimSpace(link.Email))
+
+	var guest models.User
+	err := as.DB.Where("email = ? AND role = ?", email, "guest").First(&guest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", errors.New("invalid claim link")
+	}
+	if err != nil {
+		return "", errors.New("error loading guest: " + err.Error())
+	}
+
+	if err := verifyGuestClaimLink(&guest, link); err != nil {
+		return "", err
+	}
+
+	user := models.User{Name: req.Name, Role: "customer"}
+	if err := user.HashPassword(req.Password); err != nil {
+		return "", errors.New("error hashing password")
+	}
+
+	tx := as.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// The role condition makes a second use of the same link fail.
+	result := tx.Model(&models.User{}).Where("id = ? AND role = ?", guest.ID, "guest").
+		Updates(map[string]any{"name": user.Name, "password": user.Password, "role": user.Role})
+	if result.Error != nil {
+		tx.Rollback()
+		return "", errors.New("error claiming guest: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return "", errors.New("invalid claim link")
+	}
+
+	// The guest orders were all placed with the email the link was sent to and move with the
+	// account. Addresses left by older checkouts were typed in by whoever used the email, so they
+	// are not handed over; the orders keep their own copy.
+	if err := tx.Where("user_id = ?", guest.ID).Delete(&models.Address{}).Error; err != nil {
+		tx.Rollback()
+		return "", errors.New("error deleting guest addresses: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return "", errors.New("error committing transaction: " + err.Error())
+	}
+
+	token, err := utils.GenerateToken(guest.ID)
+	if err != nil {
+		return "", errors.New("error generating token")
+	}
+
+	return token, nil
+}
+
+// queueGuestClaim queues the email with the claim link for a guest, unless one is still waiting to
+// be sent. The link itself is signed when the email is rendered.
+func queueGuestClaim(db *gorm.DB, guest *models.User) error {
+	var pending int64
+	err := db.Model(&models.Notification{}).
+		Where("user_id = ? AND jenis = ? AND status IN ?", guest.ID, guestClaimNotification, []string{"pending", "sending"}).
+		Count(&pending).Error
+	if err != nil {
+		return errors.New("error loading notifications: " + err.Error())
+	}
+
+	if pending > 0 {
+		return nil
+	}
+
+	preference, err := notificationPreference(db, guest.ID)
+	if err != nil {
+		return errors.New("error loading notification preferences: " + err.Error())
+	}
+
+	notification := models.Notification{
+		UserID: guest.ID,
+		Jenis:  guestClaimNotification,
+		Bahasa: preference.Bahasa,
+		Status: "pending",
+	}
+
+	if err := db.Create(&notification).Error; err != nil {
+		return errors.New("error queueing notification: " + err.Error())
+	}
+
+	return nil
+}
+
+func guestClaimLink(guest *models.User, berlakuSampai time.Time) string {
+	expires := berlakuSampai.Unix()
+	signature := utils.SignPayload(config.GetGuestOrderLinkSecret(), guestClaimLinkPayload(guest.ID, guest.Email, expires))
+
+	return fmt.Sprintf("%s/api/guest/claim?email=%s&expires=%d&signature=%s", config.GetAppBaseURL(), url.QueryEscape(guest.Email), expires, signature)
+}
+
+func verifyGuestClaimLink(guest *models.User, link *models.GuestClaimLinkRequest) error {
+	payload := guestClaimLinkPayload(guest.ID, guest.Email, link.Expires)
+	if !utils.VerifyPayloadSignature(config.GetGuestOrderLinkSecret(), payload, link.Signature) {
+		return errors.New("invalid claim link")
+	}
+
+	if time.Now().Unix() > link.Expires {
+		return errors.New("claim link has expired")
+	}
+
+	return nil
+}
+
+func guestClaimLinkPayload(userID uint, email string, expires int64) []byte {
+	return []byte(fmt.Sprintf("guest-claim:%d:%s:%d", userID, email, expires))
+}
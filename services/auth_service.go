@@ -19,11 +19,26 @@ func NewAuthService(db *gorm.DB) *AuthService {
 }
 
 func (as *AuthService) Register(user *models.User) (string, error) {
+	user.Role = "customer"
+
 	if err := user.HashPassword(user.Password); err != nil {
 		return "", errors.New("error hashing password")
 	}
 
-	if err := as.DB.Create(user).Error; err != nil {
+	// The email of a guest checkout is only turned into an account through the claim link sent to
+	// it, anyone can check out with any email.
+	var guest models.User
+	err := as.DB.Where("email = ? AND role = ?", user.Email, "guest").First(&guest).Error
+	if err == nil {
+		if err := queueGuestClaim(as.DB, &guest); err != nil {
+			return "", err
+		}
+		return "", errors.New("email was used for a guest checkout, a link to claim it was sent to the email")
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := as.DB.Create(user).Error; err != nil {
+			return "", errors.New("error creating user")
+		}
+	} else {
 		return "", errors.New("error creating user")
 	}
 
@@ -58,3 +73,19 @@ func (as *AuthService) Login(loginReq *models.LoginRequest) (string, error) {
 
 	return token, nil
 }
+
+func userHasRole(db *gorm.DB, userID uint, roles ...string) bool {
+	var user models.User
+
+	if err := db.Select("id", "role").First(&user, userID).Error; err != nil {
+		return false
+	}
+
+	for _, role := range roles {
+		if user.Role == role {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"golang-api/models"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type CurrencyService struct {
+	DB *gorm.DB
+}
+
+func NewCurrencyService(db *gorm.DB) *CurrencyService {
+	return &CurrencyService{DB: db}
+}
+
+func (cs *CurrencyService) GetExchangeRates() ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+
+	if err := cs.DB.Order("mata_uang ASC").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("no exchange rates found")
+	}
+
+	return rates, nil
+}
+
+func (cs *CurrencyService) SetExchangeRate(mataUang string, kurs float64) (*models.ExchangeRate, error) {
+	return setExchangeRate(cs.DB, mataUang, kurs)
+}
+
+func (cs *CurrencyService) DeleteExchangeRate(mataUang string) error {
+	code, err := normalizeCurrency(mataUang)
+	if err != nil {
+		return err
+	}
+
+	var products int64
+	if err := cs.DB.Model(&models.Product{}).Where("mata_uang = ?", code).Count(&products).Error; err != nil {
+		return err
+	}
+
+	if products > 0 {
+		return errors.New("exchange rate is still used by products")
+	}
+
+	// Open orders keep their locked rates, but new items may still need this one.
+	var orders int64
+	err = cs.DB.Model(&models.Order{}).
+		Where("mata_uang = ? AND status NOT IN ?", code, []string{"delivered", "cancelled"}).
+		Count(&orders).Error
+	if err != nil {
+		return err
+	}
+
+	if orders > 0 {
+		return errors.New("exchange rate is still used by open orders")
+	}
+
+	result := cs.DB.Unscoped().Where("mata_uang = ?", code).Delete(&models.ExchangeRate{})
+	if result.Error != nil {
+		return errors.New("error deleting exchange rate: " + result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("exchange rate not found")
+	}
+
+	return nil
+}
+
+func (cs *CurrencyService) ImportExchangeRates(r io.Reader) ([]models.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("error reading exchange rate file: " + err.Error())
+	}
+
+	if len(records) > 0 {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(records[0][1]), 64); err != nil {
+			records = records[1:]
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("exchange rate file is empty")
+	}
+
+	tx := cs.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var rates []models.ExchangeRate
+	for i, record := range records {
+		kurs, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("invalid rate on line %d: %s", i+1, record[1])
+		}
+
+		rate, err := setExchangeRate(tx, record[0], kurs)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+
+		rates = append(rates, *rate)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return rates, nil
+}
+
+// ImportExchangeRateFile loads the rates of a CSV file with mata_uang,kurs lines at startup.
+func ImportExchangeRateFile(db *gorm.DB, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = NewCurrencyService(db).ImportExchangeRates(file)
+	return err
+}
+
+func setExchangeRate(tx *gorm.DB, mataUang string, kurs float64) (*models.ExchangeRate, error) {
+	code, err := normalizeCurrency(mataUang)
+	if err != nil {
+		return nil, err
+	}
+
+	if code == models.BaseCurrency {
+		return nil, errors.New("the rate of the base currency " + models.BaseCurrency + " is always 1")
+	}
+
+	if kurs <= 0 || kursToMicro(kurs) == 0 {
+		return nil, errors.New("exchange rate must be greater than 0")
+	}
+
+	var rate models.ExchangeRate
+	err = tx.Where("mata_uang = ?", code).First(&rate).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	rate.MataUang = code
+	rate.Kurs = kurs
+
+	if err := tx.Save(&rate).Error; err != nil {
+		return nil, errors.New("error saving exchange rate: " + err.Error())
+	}
+
+	return &rate, nil
+}
+
+func normalizeCurrency(mataUang string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(mataUang))
+
+	if len(code) != 3 || strings.IndexFunc(code, func(r rune) bool { return r < 'A' || r > 'Z' }) != -1 {
+		return "", errors.New("invalid currency code: " + mataUang)
+	}
+
+	return code, nil
+}
+
+func getExchangeRate(tx *gorm.DB, mataUang string) (float64, error) {
+	code, err := normalizeCurrency(mataUang)
+	if err != nil {
+		return 0, err
+	}
+
+	if code == models.BaseCurrency {
+		return 1, nil
+	}
+
+	var rate models.ExchangeRate
+	if err := tx.Where("mata_uang = ?", code).First(&rate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errors.New("unsupported currency: " + code)
+		}
+		return 0, err
+	}
+
+	return rate.Kurs, nil
+}
+
+// productPrice returns the price of a product in the currency of an order, using rates locked on
+// the order for both currencies, so later rate changes never move prices within an order.
+func productPrice(tx *gorm.DB, order *models.Order, product *models.Product) (models.Money, error) {
+	mataUang := product.MataUang
+	if mataUang == "" {
+		mataUang = models.BaseCurrency
+	}
+
+	if mataUang == order.MataUang {
+		return product.Harga, nil
+	}
+
+	kurs, err := orderExchangeRate(tx, order, mataUang)
+	if err != nil {
+		return 0, err
+	}
+
+	return convertMoney(product.Harga, kurs, order.Kurs), nil
+}
+
+// orderExchangeRate returns the rate of a currency locked on the order, locking the current rate
+// when the order did not need the currency before.
+func orderExchangeRate(tx *gorm.DB, order *models.Order, mataUang string) (float64, error) {
+	if mataUang == models.BaseCurrency {
+		return 1, nil
+	}
+
+	var locked models.OrderExchangeRate
+	err := tx.Where("order_id = ? AND mata_uang = ?", order.ID, mataUang).First(&locked).Error
+	if err == nil {
+		return locked.Kurs, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	kurs, err := getExchangeRate(tx, mataUang)
+	if err != nil {
+		return 0, err
+	}
+
+	locked = models.OrderExchangeRate{
+		OrderID:  order.ID,
+		MataUang: mataUang,
+		Kurs:     kurs,
+	}
+
+	if err := tx.Create(&locked).Error; err != nil {
+		return 0, errors.New("error locking exchange rate: " + err.Error())
+	}
+
+	return kurs, nil
+}
+
+// convertMoney converts an amount between two currencies given their rates to the base currency.
+func convertMoney(amount models.Money, fromKurs float64, toKurs float64) models.Money {
+	if fromKurs == toKurs {
+		return amount
+	}
+
+	return amount.MulDiv(kursToMicro(fromKurs), kursToMicro(toKurs))
+}
+
+func kursToMicro(kurs float64) int64 {
+	return int64(math.Round(kurs * 1000000))
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"golang-api/config"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type EmailMessage struct {
+	Dari     string
+	Penerima string
+	Subjek   string
+	Isi      string
+}
+
+// Bytes returns the message in RFC 5322 format as plain UTF-8 text.
+func (m *EmailMessage) Bytes() []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", m.Dari)
+	fmt.Fprintf(&buf, "To: %s\r\n", m.Penerima)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subjek))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(m.Isi, "\n", "\r\n"))
+
+	return buf.Bytes()
+}
+
+type EmailSender interface {
+	Name() string
+	Send(message *EmailMessage) error
+}
+
+// LogEmailSender writes the recipient and subject of emails to the application log instead of
+// sending them. The body is left out, it can hold signed links that only the recipient may see;
+// use FileEmailSender to read emails during development.
+type LogEmailSender struct{}
+
+func (s *LogEmailSender) Name() string {
+	return "log"
+}
+
+func (s *LogEmailSender) Send(message *EmailMessage) error {
+	log.Printf("Email to %s: %s", message.Penerima, message.Subjek)
+	return nil
+}
+
+// FileEmailSender stores every email as an .eml file, so it can be opened with a mail client.
+type FileEmailSender struct {
+	Dir string
+}
+
+func (s *FileEmailSender) Name() string {
+	return "file"
+}
+
+func (s *FileEmailSender) Send(message *EmailMessage) error {
+	if err := os.MkdirAll(s.Dir, 0775); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+
+	penerima := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(message.Penerima)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000"), penerima)
+
+	return os.WriteFile(filepath.Join(s.Dir, name), message.Bytes(), 0664)
+}
+
+type SMTPEmailSender struct {
+	Settings config.SMTPSettings
+}
+
+func (s *SMTPEmailSender) Name() string {
+	return "smtp"
+}
+
+func (s *SMTPEmailSender) Send(message *EmailMessage) error {
+	var auth smtp.Auth
+	if s.Settings.Username != "" {
+		auth = smtp.PlainAuth("", s.Settings.Username, s.Settings.Password, s.Settings.Host)
+	}
+
+	// The envelope needs the bare address when EMAIL_FROM also carries a display name.
+	dari := message.Dari
+	if address, err := mail.ParseAddress(message.Dari); err == nil {
+		dari = address.Address
+	}
+
+	addr := net.JoinHostPort(s.Settings.Host, s.Settings.Port)
+
+	return smtp.SendMail(addr, auth, dari, []string{message.Penerima}, message.Bytes())
+}
+
+func NewEmailSenders() map[string]EmailSender {
+	senders := make(map[string]EmailSender)
+
+	for _, sender := range []EmailSender{&LogEmailSender{}, &FileEmailSender{Dir: config.GetEmailFileDir()}} {
+		senders[sender.Name()] = sender
+	}
+
+	if settings := config.GetSMTPSettings(); settings.Host != "" {
+		smtpSender := &SMTPEmailSender{Settings: settings}
+		senders[smtpSender.Name()] = smtpSender
+	}
+
+	return senders
+}
@@ -5,6 +5,7 @@ import (
 	"golang-api/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type InventoryService struct {
@@ -32,10 +33,32 @@ func (is *InventoryService) CreateInventory(inventory *models.Inventory) (*model
 		return nil, err
 	}
 
-	if err := is.DB.Create(inventory).Error; err != nil {
+	tx := is.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Create(inventory).Error; err != nil {
+		tx.Rollback()
 		return nil, errors.New("error creating inventory: " + err.Error())
 	}
 
+	if err := publishStockChanged(tx, inventory, 0); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if _, err := allocateBackorders(tx, inventory.ProductID); err != nil {
+		tx.Rollback()
+		return nil, errors.New("error allocating backorders: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
 	if err := is.DB.Preload("Product").First(inventory, inventory.ID).Error; err != nil {
 		return nil, errors.New("error loading inventory with product")
 	}
@@ -96,7 +119,7 @@ func (is *InventoryService) GetInventoryByProductAndLocation(productID uint, lok
 	return &inventory, nil
 }
 
-func (is *InventoryService) UpdateInventory(inventory *models.Inventory) (*models.Inventory, error) {
+func (is *InventoryService) UpdateInventory(inventory *models.Inventory, versi uint) (*models.Inventory, error) {
 	var product models.Product
 	if err := is.DB.First(&product, inventory.ProductID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -105,8 +128,47 @@ func (is *InventoryService) UpdateInventory(inventory *models.Inventory) (*model
 		return nil, err
 	}
 
-	if err := is.DB.Save(inventory).Error; err != nil {
-		return nil, errors.New("error updating inventory: " + err.Error())
+	tx := is.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var current models.Inventory
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, inventory.ID).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("inventory not found")
+		}
+		return nil, err
+	}
+
+	inventory.Versi = versi + 1
+	result := tx.Model(inventory).Where("versi = ?", versi).Select("*").Updates(inventory)
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating inventory: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return nil, ErrVersionConflict
+	}
+
+	if err := publishStockChanged(tx, inventory, current.Jumlah); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if inventory.Jumlah > current.Jumlah {
+		if _, err := allocateBackorders(tx, inventory.ProductID); err != nil {
+			tx.Rollback()
+			return nil, errors.New("error allocating backorders: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
 	}
 
 	if err := is.DB.Preload("Product").First(inventory, inventory.ID).Error; err != nil {
@@ -116,28 +178,62 @@ func (is *InventoryService) UpdateInventory(inventory *models.Inventory) (*model
 	return inventory, nil
 }
 
+// UpdateStock adds or removes stock at a location. Incoming stock is allocated to backordered
+// order items of the product, oldest order first.
 func (is *InventoryService) UpdateStock(req *models.UpdateStockRequest) (*models.Inventory, error) {
-	inventory, err := is.GetInventoryByProductAndLocation(req.ProductID, req.Lokasi)
+	tx := is.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var inventory models.Inventory
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ? AND lokasi = ?", req.ProductID, req.Lokasi).First(&inventory).Error
 	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("inventory not found for this product and location")
+		}
 		return nil, err
 	}
 
 	newStock := inventory.Jumlah + req.Jumlah
 	if newStock < 0 {
+		tx.Rollback()
 		return nil, errors.New("insufficient stock - operation would result in negative stock")
 	}
 
+	jumlahSebelum := inventory.Jumlah
 	inventory.Jumlah = newStock
+	inventory.Versi++
 
-	if err := is.DB.Save(inventory).Error; err != nil {
+	if err := tx.Save(&inventory).Error; err != nil {
+		tx.Rollback()
 		return nil, errors.New("error updating stock: " + err.Error())
 	}
 
-	if err := is.DB.Preload("Product").First(inventory, inventory.ID).Error; err != nil {
+	if err := publishStockChanged(tx, &inventory, jumlahSebelum); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if req.Jumlah > 0 {
+		if _, err := allocateBackorders(tx, inventory.ProductID); err != nil {
+			tx.Rollback()
+			return nil, errors.New("error allocating backorders: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	if err := is.DB.Preload("Product").First(&inventory, inventory.ID).Error; err != nil {
 		return nil, errors.New("error loading inventory with product")
 	}
 
-	return inventory, nil
+	return &inventory, nil
 }
 
 func (is *InventoryService) DeleteInventory(id uint) error {
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"golang-api/utils"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invoiceTotal struct {
+	label string
+	value models.Money
+	bold  bool
+}
+
+type InvoiceService struct {
+	DB *gorm.DB
+}
+
+func NewInvoiceService(db *gorm.DB) *InvoiceService {
+	return &InvoiceService{DB: db}
+}
+
+// invoicedOrderStatuses are the statuses of paid orders, the only ones that get an invoice.
+var invoicedOrderStatuses = []string{"confirmed", "partially_shipped", "shipped", "delivered"}
+
+func (is *InvoiceService) GetInvoice(orderID uint, userID uint) (*models.Invoice, error) {
+	var order models.Order
+	if err := is.DB.First(&order, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	if order.UserID != userID && !userHasRole(is.DB, userID, "admin", "staff") {
+		return nil, errors.New("order not found")
+	}
+
+	var invoice models.Invoice
+	err := is.DB.Where("order_id = ?", order.ID).First(&invoice).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && invoice.TanggalOrder.IsZero()) {
+		// An invoice issued before invoices kept their own copy is completed from the order once.
+		if err := is.issueInvoice(order.ID); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = is.DB.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("order_id = ?", order.ID).First(&invoice).Error
+	if err != nil {
+		return nil, errors.New("error loading invoice")
+	}
+
+	return &invoice, nil
+}
+
+func (is *InvoiceService) issueInvoice(orderID uint) error {
+	tx := is.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("User").Preload("OrderItems", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).First(&order, orderID).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var invoice models.Invoice
+	err = tx.Where("order_id = ?", order.ID).First(&invoice).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return err
+	}
+
+	if !invoice.TanggalOrder.IsZero() {
+		tx.Rollback()
+		return nil
+	}
+
+	if invoice.ID == 0 {
+		if !slices.Contains(invoicedOrderStatuses, order.Status) {
+			tx.Rollback()
+			return errors.New("invoices are only issued for paid orders")
+		}
+
+		now := time.Now()
+		prefix := config.GetInvoicePrefix()
+
+		urutan, err := nextSequence(tx, fmt.Sprintf("invoice:%s:%d", prefix, now.Year()))
+		if err != nil {
+			tx.Rollback()
+			return errors.New("error generating invoice number: " + err.Error())
+		}
+
+		invoice.OrderID = order.ID
+		invoice.NomorInvoice = fmt.Sprintf("%s/%d/%06d", prefix, now.Year(), urutan)
+		invoice.TanggalInvoice = now
+	}
+
+	invoice.NomorOrder = order.NomorOrder
+	invoice.TanggalOrder = order.TanggalOrder
+	invoice.NamaPelanggan = order.User.Name
+	invoice.EmailPelanggan = order.User.Email
+	invoice.AlamatPengiriman = order.AlamatPengiriman
+	invoice.MetodePengiriman = order.MetodePengiriman
+	invoice.MataUang = order.MataUang
+	invoice.Subtotal = order.Subtotal
+	invoice.TotalPajak = order.TotalPajak
+	invoice.HargaTermasukPajak = order.HargaTermasukPajak
+	invoice.OngkosKirim = order.OngkosKirim
+	invoice.TotalHarga = order.TotalHarga
+
+	if err := tx.Omit("Order", "Items").Save(&invoice).Error; err != nil {
+		tx.Rollback()
+		return errors.New("error creating invoice: " + err.Error())
+	}
+
+	for _, item := range order.OrderItems {
+		invoiceItem := models.InvoiceItem{
+			InvoiceID:  invoice.ID,
+			NamaProduk: item.Product.Nama,
+			Jumlah:     item.Jumlah,
+			Harga:      item.Harga,
+			Pajak:      item.Pajak,
+			Subtotal:   item.Subtotal,
+		}
+
+		if err := tx.Create(&invoiceItem).Error; err != nil {
+			tx.Rollback()
+			return errors.New("error creating invoice item: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return errors.New("error committing transaction: " + err.Error())
+	}
+
+	return nil
+}
+
+func (is *InvoiceService) RenderPDF(invoice *models.Invoice) []byte {
+	const (
+		left   = 40.0
+		right  = utils.PDFPageWidth - 40
+		bottom = utils.PDFPageHeight - 60
+	)
+
+	seller := config.GetInvoiceSeller()
+	doc := utils.NewPDFDocument()
+	page := doc.AddPage()
+
+	page.Text(left, 60, 18, true, seller.Nama)
+	y := 78.0
+	for _, line := range []string{seller.Alamat, seller.Telepon, seller.Email} {
+		if line != "" {
+			page.Text(left, y, 9, false, line)
+			y += 12
+		}
+	}
+	if seller.NPWP != "" {
+		page.Text(left, y, 9, false, "NPWP: "+seller.NPWP)
+	}
+
+	page.TextRight(right, 60, 20, true, "INVOICE")
+	page.TextRight(right, 80, 10, false, "No: "+invoice.NomorInvoice)
+	page.TextRight(right, 94, 10, false, "Tanggal: "+invoice.TanggalInvoice.Format("02-01-2006"))
+	page.TextRight(right, 108, 10, false, fmt.Sprintf("Order: %s (%s)", invoice.NomorOrder, invoice.TanggalOrder.Format("02-01-2006")))
+
+	page.Line(left, 140, right, 140, 0.8)
+
+	page.Text(left, 160, 10, true, "Tagihan kepada")
+	page.Text(left, 174, 10, false, invoice.NamaPelanggan)
+	page.Text(left, 188, 10, false, invoice.EmailPelanggan)
+
+	alamat := invoice.AlamatPengiriman
+	page.Text(300, 160, 10, true, "Dikirim ke")
+	y = 174
+	for _, line := range []string{
+		alamat.NamaPenerima,
+		alamat.Telepon,
+		alamat.Alamat,
+		strings.Trim(strings.Join([]string{alamat.Kota, alamat.Provinsi, alamat.KodePos}, " "), " "),
+	} {
+		if line != "" {
+			page.Text(300, y, 10, false, utils.PDFTruncate(line, right-300, 10, false))
+			y += 14
+		}
+	}
+
+	y = math.Max(y, 202) + 16
+
+	columns := []struct {
+		title string
+		x     float64
+		right bool
+	}{
+		{"No", left + 4, false},
+		{"Produk", left + 28, false},
+		{"Qty", 300, true},
+		{"Harga", 390, true},
+		{"PPN", 460, true},
+		{"Subtotal", right - 4, true},
+	}
+
+	drawHeader := func() {
+		page.FillRect(left, y-12, right-left, 18, 0.9)
+		for _, column := range columns {
+			if column.right {
+				page.TextRight(column.x, y, 9, true, column.title)
+			} else {
+				page.Text(column.x, y, 9, true, column.title)
+			}
+		}
+		y += 20
+	}
+
+	drawHeader()
+
+	for i, item := range invoice.Items {
+		if y > bottom {
+			page = doc.AddPage()
+			y = 60
+			drawHeader()
+		}
+
+		page.Text(left+4, y, 9, false, fmt.Sprintf("%d", i+1))
+		page.Text(left+28, y, 9, false, utils.PDFTruncate(item.NamaProduk, 200, 9, false))
+		page.TextRight(300, y, 9, false, fmt.Sprintf("%d", item.Jumlah))
+		page.TextRight(390, y, 9, false, formatMoney(item.Harga, invoice.MataUang))
+		page.TextRight(460, y, 9, false, formatMoney(item.Pajak, invoice.MataUang))
+		page.TextRight(right-4, y, 9, false, formatMoney(item.Subtotal, invoice.MataUang))
+		page.Line(left, y+6, right, y+6, 0.3)
+		y += 18
+	}
+
+	if y > bottom-110 {
+		page = doc.AddPage()
+		y = 60
+	}
+
+	y += 10
+	totals := []invoiceTotal{
+		{"Subtotal", invoice.Subtotal, false},
+		{"PPN", invoice.TotalPajak, false},
+		{"Ongkos kirim (" + invoice.MetodePengiriman + ")", invoice.OngkosKirim, false},
+		{"Total", invoice.TotalHarga, true},
+	}
+
+	for _, total := range totals {
+		page.Text(330, y, 10, total.bold, total.label)
+		page.TextRight(right-4, y, 10, total.bold, formatMoney(total.value, invoice.MataUang))
+		y += 16
+	}
+
+	if invoice.HargaTermasukPajak {
+		page.Text(left, y+10, 8, false, "Harga produk sudah termasuk PPN.")
+	}
+
+	page.Text(left, utils.PDFPageHeight-30, 8, false, "Invoice ini dibuat secara elektronik dan sah tanpa tanda tangan.")
+
+	return doc.Bytes()
+}
+
+func formatMoney(value models.Money, mataUang string) string {
+	sign := ""
+	cents := int64(value)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	whole := fmt.Sprintf("%d", cents/100)
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	symbol := mataUang
+	if mataUang == "" || mataUang == models.BaseCurrency {
+		symbol = "Rp"
+	}
+
+	return fmt.Sprintf("%s%s %s,%02d", sign, symbol, grouped.String(), cents%100)
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"golang-api/config"
+	"golang-api/models"
+	"golang-api/templates"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const notificationSendBatch = 100
+
+// notificationClaimLease is how long a notification stays claimed by the instance sending it.
+const notificationClaimLease = 5 * time.Minute
+
+type NotificationService struct {
+	DB      *gorm.DB
+	Senders map[string]EmailSender
+}
+
+func NewNotificationService(db *gorm.DB) *NotificationService {
+	return &NotificationService{
+		DB:      db,
+		Senders: NewEmailSenders(),
+	}
+}
+
+type orderNotificationItem struct {
+	Nama     string
+	Jumlah   int
+	Subtotal string
+}
+
+type orderNotificationData struct {
+	Toko             string
+	Nama             string
+	NomorOrder       string
+	Tanggal          string
+	Status           string
+	StatusSebelum    string
+	AlasanPembatalan string
+	Total            string
+	LinkPelacakan    string
+	Items            []orderNotificationItem
+}
+
+type guestClaimNotificationData struct {
+	Toko          string
+	Nama          string
+	LinkKlaim     string
+	BerlakuSampai string
+}
+
+// GetPreference returns the notification settings of a user. Users who never changed them get
+// every email in NOTIFICATION_DEFAULT_LANGUAGE.
+func (ns *NotificationService) GetPreference(userID uint) (*models.NotificationPreference, error) {
+	return notificationPreference(ns.DB, userID)
+}
+
+func (ns *NotificationService) UpdatePreference(userID uint, req *models.UpdateNotificationPreferenceRequest) (*models.NotificationPreference, error) {
+	preference, err := notificationPreference(ns.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Bahasa != "" {
+		preference.Bahasa = req.Bahasa
+	}
+	if req.EmailOrderBaru != nil {
+		preference.EmailOrderBaru = *req.EmailOrderBaru
+	}
+	if req.EmailStatusOrder != nil {
+		preference.EmailStatusOrder = *req.EmailStatusOrder
+	}
+
+	if err := ns.DB.Save(preference).Error; err != nil {
+		return nil, errors.New("error saving notification preferences: " + err.Error())
+	}
+
+	return preference, nil
+}
+
+func (ns *NotificationService) GetNotifications(userID uint, req *models.GetNotificationRequest) ([]models.Notification, int64, error) {
+	query := ns.DB.Model(&models.Notification{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []models.Notification
+	if err := query.Order("id DESC").Limit(req.Limit).Offset(req.Offset).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(notifications) == 0 {
+		return nil, 0, errors.New("no notifications found")
+	}
+
+	return notifications, total, nil
+}
+
+// SendPendingNotifications renders and sends the queued emails through the EMAIL_SENDER. An email
+// that cannot be sent is tried again on the next runs and marked failed after
+// NOTIFICATION_MAX_ATTEMPTS attempts.
+func (ns *NotificationService) SendPendingNotifications(now time.Time) (int, error) {
+	var notifications []models.Notification
+	err := ns.DB.Where("status = ? OR (status = ? AND diklaim_pada <= ?)", "pending", "sending", now.Add(-notificationClaimLease)).
+		Order("id ASC").Limit(notificationSendBatch).Find(&notifications).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+
+	sender, ok := ns.Senders[config.GetEmailSender()]
+	if !ok {
+		return 0, errors.New("unsupported email sender: " + config.GetEmailSender())
+	}
+
+	sent := 0
+	for i := range notifications {
+		notification := &notifications[i]
+
+		claimed, err := ns.claimNotification(notification, now)
+		if err != nil {
+			return sent, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		sendErr := ns.renderNotification(notification)
+		if sendErr == nil {
+			sendErr = sender.Send(&EmailMessage{
+				Dari:     config.GetEmailFrom(),
+				Penerima: notification.Penerima,
+				Subjek:   notification.Subjek,
+				Isi:      notification.Isi,
+			})
+		}
+
+		notification.Percobaan++
+		if sendErr == nil {
+			notification.Status = "sent"
+			notification.TerkirimPada = &now
+			notification.PesanGagal = ""
+			sent++
+		} else {
+			notification.Status = "pending"
+			notification.PesanGagal = sendErr.Error()
+			if notification.Percobaan >= config.GetNotificationMaxAttempts() {
+				notification.Status = "failed"
+			}
+
+			log.Printf("Notification %d could not be sent to %s: %s", notification.ID, notification.Penerima, sendErr.Error())
+		}
+
+		err = ns.DB.Model(notification).Select("penerima", "subjek", "isi", "status", "percobaan", "pesan_gagal", "terkirim_pada").Updates(notification).Error
+		if err != nil {
+			return sent, errors.New("error updating notification: " + err.Error())
+		}
+	}
+
+	return sent, nil
+}
+
+// claimNotification marks a notification as being sent, unless another instance claimed it since
+// it was loaded. A claim older than notificationClaimLease belongs to an instance that stopped while
+// sending, so the notification is claimed again.
+func (ns *NotificationService) claimNotification(notification *models.Notification, now time.Time) (bool, error) {
+	result := ns.DB.Model(&models.Notification{}).
+		Where("id = ? AND (status = ? OR (status = ? AND diklaim_pada <= ?))", notification.ID, "pending", "sending", now.Add(-notificationClaimLease)).
+		Updates(map[string]any{"status": "sending", "diklaim_pada": now})
+	if result.Error != nil {
+		return false, errors.New("error claiming notification: " + result.Error.Error())
+	}
+
+	notification.Status = "sending"
+	notification.DiklaimPada = &now
+
+	return result.RowsAffected == 1, nil
+}
+
+// renderNotification renders the email of a queued notification from the current order and customer.
+func (ns *NotificationService) renderNotification(notification *models.Notification) error {
+	var user models.User
+	if err := ns.DB.First(&user, notification.UserID).Error; err != nil {
+		return errors.New("error loading user: " + err.Error())
+	}
+
+	if user.Email == "" {
+		return errors.New("user has no email address")
+	}
+
+	var data any
+	if notification.Jenis == guestClaimNotification {
+		berlakuSampai := time.Now().Add(config.GetGuestClaimLinkTTL())
+		data = guestClaimNotificationData{
+			Toko:          config.GetInvoiceSeller().Nama,
+			Nama:          user.Name,
+			LinkKlaim:     guestClaimLink(&user, berlakuSampai),
+			BerlakuSampai: berlakuSampai.Format("2006-01-02 15:04"),
+		}
+	} else {
+		orderData, err := ns.orderNotificationData(notification, &user)
+		if err != nil {
+			return err
+		}
+		data = orderData
+	}
+
+	subjek, isi, err := renderEmail(notification.Bahasa, notification.Jenis, data)
+	if err != nil {
+		return errors.New("error rendering notification: " + err.Error())
+	}
+
+	notification.Penerima = user.Email
+	notification.Subjek = subjek
+	notification.Isi = isi
+
+	return nil
+}
+
+func (ns *NotificationService) orderNotificationData(notification *models.Notification, user *models.User) (*orderNotificationData, error) {
+	if notification.OrderID == nil {
+		return nil, errors.New("order no longer exists")
+	}
+
+	var order models.Order
+	if err := ns.DB.First(&order, *notification.OrderID).Error; err != nil {
+		return nil, errors.New("error loading order: " + err.Error())
+	}
+
+	var items []models.OrderItem
+	err := ns.DB.Preload("Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("order_id = ?", order.ID).Order("id ASC").Find(&items).Error
+	if err != nil {
+		return nil, errors.New("error loading order items: " + err.Error())
+	}
+
+	data := orderNotificationData{
+		Toko:             config.GetInvoiceSeller().Nama,
+		Nama:             user.Name,
+		NomorOrder:       order.NomorOrder,
+		Tanggal:          order.TanggalOrder.Format("2006-01-02 15:04"),
+		Status:           notification.StatusOrder,
+		StatusSebelum:    notification.StatusSebelum,
+		AlasanPembatalan: order.AlasanPembatalan,
+		Total:            formatMoney(order.TotalHarga, order.MataUang),
+	}
+	if user.Role == "guest" {
+		data.LinkPelacakan = guestOrderLink(order.NomorOrder, time.Now().Add(config.GetGuestOrderLinkTTL()))
+	}
+	for _, item := range items {
+		data.Items = append(data.Items, orderNotificationItem{
+			Nama:     item.Product.Nama,
+			Jumlah:   item.Jumlah,
+			Subtotal: formatMoney(item.Subtotal, order.MataUang),
+		})
+	}
+
+	return &data, nil
+}
+
+// SendNotifications is the scheduled job that sends the queued customer emails.
+func (ns *NotificationService) SendNotifications() {
+	sent, err := ns.SendPendingNotifications(time.Now())
+	if err != nil {
+		log.Println("Error sending notifications: " + err.Error())
+	} else if sent > 0 {
+		log.Printf("Sent %d notifications", sent)
+	}
+}
+
+func notificationPreference(tx *gorm.DB, userID uint) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+
+	err := tx.Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.NotificationPreference{
+			UserID:           userID,
+			Bahasa:           config.GetNotificationDefaultLanguage(),
+			EmailOrderBaru:   true,
+			EmailStatusOrder: true,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &preference, nil
+}
+
+// queueOrderNotification queues the email about an order event for its customer, in the language
+// the customer chose. It runs in the caller's transaction, so a notification that cannot be queued
+// is logged instead of failing the order change.
+func queueOrderNotification(tx *gorm.DB, event string, order *models.Order, statusSebelum string) {
+	preference, err := notificationPreference(tx, order.UserID)
+	if err != nil {
+		log.Printf("Notification for order %s could not be queued: %s", order.NomorOrder, err.Error())
+		return
+	}
+
+	switch event {
+	case models.WebhookEventOrderCreated:
+		if !preference.EmailOrderBaru {
+			return
+		}
+	case models.WebhookEventOrderStatusChanged:
+		if !preference.EmailStatusOrder {
+			return
+		}
+	default:
+		return
+	}
+
+	notification := models.Notification{
+		UserID:        order.UserID,
+		OrderID:       &order.ID,
+		Jenis:         strings.ReplaceAll(event, ".", "_"),
+		Bahasa:        preference.Bahasa,
+		StatusOrder:   order.Status,
+		StatusSebelum: statusSebelum,
+		Status:        "pending",
+	}
+
+	if err := tx.Create(&notification).Error; err != nil {
+		log.Printf("Notification for order %s could not be queued: %s", order.NomorOrder, err.Error())
+	}
+}
+
+func renderEmail(bahasa string, jenis string, data any) (string, string, error) {
+	tmpl, err := templates.Email(bahasa, jenis)
+	if err != nil {
+		return "", "", err
+	}
+
+	var subjek, isi bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subjek, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.ExecuteTemplate(&isi, "body", data); err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(subjek.String()), strings.TrimSpace(isi.String()), nil
+}
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"errors"
+	"golang-api/models"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const orderMaintenanceBatch = 100
+
+func (os *OrderService) GetDeletedOrders(req *models.GetDeletedOrderRequest) ([]models.Order, int64, error) {
+	var orders []models.Order
+
+	query := os.DB.Unscoped().Model(&models.Order{}).Where("deleted_at IS NOT NULL").Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("User").
+		Preload("OrderItems", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Order("deleted_at DESC").Limit(req.Limit).Offset(req.Offset).Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(orders) == 0 {
+		return nil, 0, errors.New("no deleted orders found")
+	}
+
+	return orders, total, nil
+}
+
+func (os *OrderService) RestoreOrder(id uint, staffID uint) (*models.Order, error) {
+	tx := os.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND deleted_at IS NOT NULL", id).First(&order).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("deleted order not found")
+		}
+		return nil, err
+	}
+
+	// Items removed while the order was still being edited stay removed.
+	removed := tx.Model(&models.OrderChange{}).Select("order_item_id").
+		Where("order_id = ? AND aksi = ? AND order_item_id IS NOT NULL", order.ID, "item_removed")
+
+	var items []models.OrderItem
+	err = tx.Unscoped().Where("order_id = ? AND deleted_at IS NOT NULL AND id NOT IN (?)", order.ID, removed).Find(&items).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if slices.Contains(activeOrderStatuses, order.Status) {
+		for i, item := range items {
+			var product models.Product
+			if err := tx.First(&product, item.ProductID).Error; err != nil {
+				tx.Rollback()
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, errors.New("a product of this order no longer exists")
+				}
+				return nil, err
+			}
+
+			jumlahBackorder, err := reserveStock(tx, &product, item.Jumlah-item.JumlahDikirim)
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			setBackorder(&items[i], &product, jumlahBackorder)
+		}
+	}
+
+	for _, item := range items {
+		err := tx.Unscoped().Model(&item).Updates(map[string]any{
+			"deleted_at":       nil,
+			"jumlah_backorder": item.JumlahBackorder,
+			"jenis_backorder":  item.JenisBackorder,
+			"tersedia_pada":    item.TersediaPada,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.New("error restoring order item: " + err.Error())
+		}
+	}
+
+	if err := tx.Unscoped().Model(&order).Update("deleted_at", nil).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error restoring order: " + err.Error())
+	}
+
+	change := models.OrderChange{
+		OrderID:      order.ID,
+		UserID:       &staffID,
+		Aksi:         "restored",
+		TotalSebelum: order.TotalHarga,
+		TotalSesudah: order.TotalHarga,
+	}
+
+	if err := tx.Create(&change).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error recording order change: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return os.GetOrderByID(order.ID)
+}
+
+// PurgeDeletedOrders permanently removes orders that were soft-deleted before the given time.
+// Orders with an invoice or a refund stay soft-deleted, see deleteOrderPermanently.
+func (os *OrderService) PurgeDeletedOrders(before time.Time) (int, error) {
+	purged := 0
+
+	invoiced, refunded := os.ordersWithFinancialRecords()
+
+	for {
+		var ids []uint
+		err := os.DB.Unscoped().Model(&models.Order{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Where("id NOT IN (?) AND id NOT IN (?)", invoiced, refunded).
+			Limit(orderMaintenanceBatch).Pluck("id", &ids).Error
+		if err != nil {
+			return purged, err
+		}
+
+		if len(ids) == 0 {
+			return purged, nil
+		}
+
+		for _, id := range ids {
+			tx := os.DB.Begin()
+
+			if err := deleteOrderPermanently(tx, id); err != nil {
+				tx.Rollback()
+				return purged, err
+			}
+
+			if err := tx.Commit().Error; err != nil {
+				return purged, errors.New("error committing transaction: " + err.Error())
+			}
+
+			purged++
+		}
+	}
+}
+
+// ArchiveOrders moves delivered orders placed before the given time into the archive tables.
+// Orders with a return that is still being processed are kept until it is settled.
+func (os *OrderService) ArchiveOrders(before time.Time) (int, error) {
+	archived := 0
+
+	openReturns := os.DB.Model(&models.ReturnRequest{}).Select("order_id").
+		Where("status IN ?", []string{"requested", "approved", "received"})
+
+	for {
+		var ids []uint
+		err := os.DB.Model(&models.Order{}).
+			Where("status = ? AND tanggal_order < ? AND id NOT IN (?)", "delivered", before, openReturns).
+			Order("id ASC").Limit(orderMaintenanceBatch).Pluck("id", &ids).Error
+		if err != nil {
+			return archived, err
+		}
+
+		if len(ids) == 0 {
+			return archived, nil
+		}
+
+		for _, id := range ids {
+			if err := os.archiveOrder(id); err != nil {
+				return archived, err
+			}
+
+			archived++
+		}
+	}
+}
+
+func (os *OrderService) archiveOrder(id uint) error {
+	tx := os.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("User", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).First(&order, id).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var invoice models.Invoice
+	if err := tx.Unscoped().Where("order_id = ?", order.ID).Limit(1).Find(&invoice).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var refunds []models.Refund
+	if err := tx.Unscoped().Where("order_id = ?", order.ID).Order("id ASC").Find(&refunds).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var referensi []string
+	err = tx.Model(&models.Payment{}).Where("order_id = ? AND status = ?", order.ID, "settled").Pluck("referensi", &referensi).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var nomorResi []string
+	err = tx.Model(&models.Shipment{}).Where("order_id = ?", order.ID).Order("id ASC").Pluck("nomor_resi", &nomorResi).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	archive := models.ArchivedOrder{
+		ID:                  order.ID,
+		NomorOrder:          order.NomorOrder,
+		UserID:              order.UserID,
+		NamaPelanggan:       order.User.Name,
+		EmailPelanggan:      order.User.Email,
+		MataUang:            order.MataUang,
+		Kurs:                order.Kurs,
+		Subtotal:            order.Subtotal,
+		TotalPajak:          order.TotalPajak,
+		HargaTermasukPajak:  order.HargaTermasukPajak,
+		AlamatPengiriman:    order.AlamatPengiriman,
+		MetodePengiriman:    order.MetodePengiriman,
+		BeratTotal:          order.BeratTotal,
+		OngkosKirim:         order.OngkosKirim,
+		TotalHarga:          order.TotalHarga,
+		TotalRefund:         order.TotalRefund,
+		TotalBersih:         order.TotalBersih,
+		Status:              order.Status,
+		NomorInvoice:        invoice.NomorInvoice,
+		ReferensiPembayaran: strings.Join(referensi, ","),
+		NomorResi:           strings.Join(nomorResi, ","),
+		TanggalOrder:        order.TanggalOrder,
+		DibuatPada:          order.CreatedAt,
+		DiarsipkanPada:      time.Now(),
+	}
+
+	if invoice.ID != 0 {
+		archive.TanggalInvoice = &invoice.TanggalInvoice
+	}
+
+	for _, refund := range refunds {
+		archive.Refunds = append(archive.Refunds, models.ArchivedRefund{
+			ID:              refund.ID,
+			ReturnRequestID: refund.ReturnRequestID,
+			Nominal:         refund.Nominal,
+			Alasan:          refund.Alasan,
+			DiprosesOleh:    refund.DiprosesOleh,
+			DibuatPada:      refund.CreatedAt,
+		})
+	}
+
+	for _, item := range order.OrderItems {
+		archive.Items = append(archive.Items, models.ArchivedOrderItem{
+			ID:         item.ID,
+			ProductID:  item.ProductID,
+			NamaProduk: item.Product.Nama,
+			Jumlah:     item.Jumlah,
+			Harga:      item.Harga,
+			Subtotal:   item.Subtotal,
+			TarifPajak: item.TarifPajak,
+			Pajak:      item.Pajak,
+		})
+	}
+
+	if err := tx.Create(&archive).Error; err != nil {
+		tx.Rollback()
+		return errors.New("error archiving order: " + err.Error())
+	}
+
+	// The invoice and refunds now live in the archive, the only place that keeps them.
+	invoices := tx.Unscoped().Model(&models.Invoice{}).Select("id").Where("order_id = ?", order.ID)
+	if err := tx.Unscoped().Where("invoice_id IN (?)", invoices).Delete(&models.InvoiceItem{}).Error; err != nil {
+		tx.Rollback()
+		return errors.New("error archiving invoice: " + err.Error())
+	}
+
+	for _, model := range []any{&models.Invoice{}, &models.Refund{}} {
+		if err := tx.Unscoped().Where("order_id = ?", order.ID).Delete(model).Error; err != nil {
+			tx.Rollback()
+			return errors.New("error archiving invoice and refunds: " + err.Error())
+		}
+	}
+
+	productIDs, err := orderProductIDs(tx, order.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := deleteOrderPermanently(tx, order.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := allocateOrderBackorders(tx, productIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return errors.New("error committing transaction: " + err.Error())
+	}
+
+	return nil
+}
+
+func (os *OrderService) GetArchivedOrders(req *models.GetArchivedOrderRequest) ([]models.ArchivedOrder, int64, error) {
+	var orders []models.ArchivedOrder
+
+	query := os.DB.Model(&models.ArchivedOrder{})
+
+	if req.NomorOrder != "" {
+		query = query.Where("nomor_order LIKE ?", "%"+req.NomorOrder+"%")
+	}
+
+	if req.UserID != 0 {
+		query = query.Where("user_id = ?", req.UserID)
+	}
+
+	if req.Email != "" {
+		query = query.Where("email_pelanggan LIKE ?", "%"+req.Email+"%")
+	}
+
+	if !req.TanggalDari.IsZero() {
+		query = query.Where("tanggal_order >= ?", req.TanggalDari)
+	}
+
+	if !req.TanggalSampai.IsZero() {
+		query = query.Where("tanggal_order < ?", req.TanggalSampai.AddDate(0, 0, 1))
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Items").Preload("Refunds").Order("tanggal_order DESC").Limit(req.Limit).Offset(req.Offset).Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(orders) == 0 {
+		return nil, 0, errors.New("no archived orders found")
+	}
+
+	return orders, total, nil
+}
+
+func (os *OrderService) GetArchivedOrderByID(id uint) (*models.ArchivedOrder, error) {
+	var order models.ArchivedOrder
+
+	if err := os.DB.Preload("Items").Preload("Refunds").First(&order, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("archived order not found")
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// ordersWithFinancialRecords returns subqueries of the IDs of orders with an invoice and with a refund.
+func (os *OrderService) ordersWithFinancialRecords() (*gorm.DB, *gorm.DB) {
+	invoiced := os.DB.Unscoped().Model(&models.Invoice{}).Select("order_id")
+	refunded := os.DB.Unscoped().Model(&models.Refund{}).Select("order_id")
+
+	return invoiced, refunded
+}
+
+// deleteOrderPermanently removes an order with every row that references it. Invoices keep their
+// gapless numbers and refunds are accounting records, so orders that still have them are refused;
+// archiveOrder moves them into the archive first.
+func deleteOrderPermanently(tx *gorm.DB, orderID uint) error {
+	for _, model := range []any{&models.Invoice{}, &models.Refund{}} {
+		var count int64
+		if err := tx.Unscoped().Model(model).Where("order_id = ?", orderID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return errors.New("orders with an invoice or refund cannot be deleted permanently")
+		}
+	}
+
+	shipments := tx.Unscoped().Model(&models.Shipment{}).Select("id").Where("order_id = ?", orderID)
+
+	if err := tx.Unscoped().Where("shipment_id IN (?)", shipments).Delete(&models.ShipmentItem{}).Error; err != nil {
+		return errors.New("error deleting shipment items: " + err.Error())
+	}
+
+	for _, model := range []any{
+		&models.Shipment{},
+		&models.ReturnRequest{},
+		&models.Payment{},
+		&models.OrderChange{},
+		&models.OrderComment{},
+		&models.OrderExchangeRate{},
+		&models.OrderItem{},
+	} {
+		if err := tx.Unscoped().Where("order_id = ?", orderID).Delete(model).Error; err != nil {
+			return errors.New("error deleting order data: " + err.Error())
+		}
+	}
+
+	if err := tx.Model(&models.SubscriptionRun{}).Where("order_id = ?", orderID).Update("order_id", nil).Error; err != nil {
+		return errors.New("error unlinking subscription runs: " + err.Error())
+	}
+
+	if err := tx.Model(&models.Notification{}).Where("order_id = ?", orderID).Update("order_id", nil).Error; err != nil {
+		return errors.New("error unlinking notifications: " + err.Error())
+	}
+
+	if err := tx.Unscoped().Delete(&models.Order{}, orderID).Error; err != nil {
+		return errors.New("error deleting order: " + err.Error())
+	}
+
+	return nil
+}
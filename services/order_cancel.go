@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"log"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// cancellableOrderStatuses are the statuses in which nothing has been shipped yet.
+var cancellableOrderStatuses = []string{"pending", "confirmed"}
+
+func (os *OrderService) CancelOrder(orderID uint, userID uint, req *models.CancelOrderRequest) (*models.Order, error) {
+	tx := os.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	// A paid order also needs a refund, which only staff can record.
+	if order.Status == "confirmed" {
+		tx.Rollback()
+		return nil, errors.New("paid orders can only be cancelled by staff")
+	}
+
+	if err := cancelOrder(tx, &order, &userID, req.Alasan, req.Komentar); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return os.GetOrderByID(order.ID)
+}
+
+// CancelExpiredOrders cancels pending orders placed before the given time on behalf of the system.
+func (os *OrderService) CancelExpiredOrders(before time.Time, komentar string) (int, error) {
+	var ids []uint
+	err := os.DB.Model(&models.Order{}).Where("status = ? AND tanggal_order < ?", "pending", before).Order("id ASC").Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, id := range ids {
+		tx := os.DB.Begin()
+
+		// The order may have been paid or edited since it was selected, so check it again under lock.
+		var order models.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND status = ? AND tanggal_order < ?", id, "pending", before).First(&order).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			tx.Rollback()
+			continue
+		}
+
+		if err == nil {
+			err = cancelOrder(tx, &order, nil, "payment_timeout", komentar)
+		}
+
+		if err != nil {
+			tx.Rollback()
+			return cancelled, err
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			return cancelled, errors.New("error committing transaction: " + err.Error())
+		}
+
+		log.Printf("Order %s automatically cancelled: %s", order.NomorOrder, komentar)
+		cancelled++
+	}
+
+	return cancelled, nil
+}
+
+// RunAutoCancel cancels pending orders that were not paid within ORDER_PAYMENT_TIMEOUT.
+func (os *OrderService) RunAutoCancel() {
+	timeout := config.GetOrderPaymentTimeout()
+	komentar := fmt.Sprintf("not paid within %s", timeout)
+
+	if _, err := os.CancelExpiredOrders(time.Now().Add(-timeout), komentar); err != nil {
+		log.Println("Error cancelling expired orders: " + err.Error())
+	}
+}
+
+// cancelOrder cancels a locked order and records who cancelled it and why; a nil actor is the system.
+// Reserved stock is released because cancelled orders no longer count as active.
+func cancelOrder(tx *gorm.DB, order *models.Order, actorID *uint, alasan string, komentar string) error {
+	if !slices.Contains(cancellableOrderStatuses, order.Status) {
+		return errors.New("order can no longer be cancelled")
+	}
+
+	now := time.Now()
+	statusSebelum := order.Status
+
+	order.Status = "cancelled"
+	order.DibatalkanOleh = actorID
+	order.DibatalkanPada = &now
+	order.AlasanPembatalan = alasan
+	order.KomentarPembatalan = komentar
+
+	err := tx.Model(order).Select("status", "dibatalkan_oleh", "dibatalkan_pada", "alasan_pembatalan", "komentar_pembatalan").Updates(order).Error
+	if err != nil {
+		return errors.New("error cancelling order: " + err.Error())
+	}
+
+	if err := bumpVersion(tx, order); err != nil {
+		return errors.New("error updating order version: " + err.Error())
+	}
+
+	if err := recordOrderEvent(tx, models.WebhookEventOrderStatusChanged, order, statusSebelum); err != nil {
+		return err
+	}
+
+	err = tx.Model(&models.Payment{}).Where("order_id = ? AND status = ?", order.ID, "pending").Update("status", "expired").Error
+	if err != nil {
+		return errors.New("error expiring pending payments: " + err.Error())
+	}
+
+	productIDs, err := orderProductIDs(tx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := allocateOrderBackorders(tx, productIDs); err != nil {
+		return err
+	}
+
+	keterangan := alasan
+	if komentar != "" {
+		keterangan += ": " + komentar
+	}
+
+	change := models.OrderChange{
+		OrderID:      order.ID,
+		UserID:       actorID,
+		Aksi:         "cancelled",
+		TotalSebelum: order.TotalHarga,
+		TotalSesudah: order.TotalHarga,
+		Keterangan:   keterangan,
+	}
+
+	if err := tx.Create(&change).Error; err != nil {
+		return errors.New("error recording order change: " + err.Error())
+	}
+
+	return nil
+}
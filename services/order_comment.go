@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"golang-api/models"
+
+	"gorm.io/gorm"
+)
+
+// ListOrderComments returns the comment thread of an order, oldest first. Internal comments are
+// only included when includeInternal is set, so customer-facing callers must pass false.
+func (os *OrderService) ListOrderComments(orderID uint, includeInternal bool) ([]models.OrderComment, error) {
+	var comments []models.OrderComment
+
+	query := os.DB.Preload("User").Where("order_id = ?", orderID)
+	if !includeInternal {
+		query = query.Where("visibilitas = ?", "customer")
+	}
+
+	if err := query.Order("created_at ASC").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (os *OrderService) GetOrderComments(orderID uint, userID uint) ([]models.OrderComment, error) {
+	if _, err := os.GetOrderByIDAndUserID(orderID, userID); err != nil {
+		return nil, err
+	}
+
+	comments, err := os.ListOrderComments(orderID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, errors.New("no order comments found")
+	}
+
+	return comments, nil
+}
+
+func (os *OrderService) GetStaffOrderComments(orderID uint) ([]models.OrderComment, error) {
+	if err := os.DB.First(&models.Order{}, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	comments, err := os.ListOrderComments(orderID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, errors.New("no order comments found")
+	}
+
+	return comments, nil
+}
+
+// AddOrderComment adds a comment of the customer to their own order. Customers can only write
+// customer-visible comments.
+func (os *OrderService) AddOrderComment(orderID uint, userID uint, req *models.AddOrderCommentRequest) (*models.OrderComment, error) {
+	if _, err := os.GetOrderByIDAndUserID(orderID, userID); err != nil {
+		return nil, err
+	}
+
+	if req.Visibilitas == "internal" {
+		return nil, errors.New("customers cannot add internal comments")
+	}
+
+	return os.createOrderComment(orderID, userID, req.Isi, "customer")
+}
+
+// AddStaffOrderComment adds a staff comment to any order. Staff comments are internal unless
+// they are explicitly made visible to the customer.
+func (os *OrderService) AddStaffOrderComment(orderID uint, staffID uint, req *models.AddOrderCommentRequest) (*models.OrderComment, error) {
+	if err := os.DB.First(&models.Order{}, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	visibilitas := req.Visibilitas
+	if visibilitas == "" {
+		visibilitas = "internal"
+	}
+
+	return os.createOrderComment(orderID, staffID, req.Isi, visibilitas)
+}
+
+func (os *OrderService) createOrderComment(orderID uint, userID uint, isi string, visibilitas string) (*models.OrderComment, error) {
+	comment := models.OrderComment{
+		OrderID:     orderID,
+		UserID:      userID,
+		Isi:         isi,
+		Visibilitas: visibilitas,
+	}
+
+	if err := os.DB.Create(&comment).Error; err != nil {
+		return nil, errors.New("error creating order comment: " + err.Error())
+	}
+
+	if err := os.DB.Preload("User").First(&comment, comment.ID).Error; err != nil {
+		return nil, errors.New("error loading order comment with user")
+	}
+
+	return &comment, nil
+}
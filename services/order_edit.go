@@ -0,0 +1,249 @@
+package services
+
+import (
+	"errors"
+	"golang-api/config"
+	"golang-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (os *OrderService) AddOrderItem(orderID uint, userID uint, req *models.AddOrderItemRequest) (*models.Order, error) {
+	return os.editPendingOrder(orderID, userID, func(tx *gorm.DB, order *models.Order) (*models.OrderChange, error) {
+		var product models.Product
+		if err := tx.First(&product, req.ProductID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("product not found")
+			}
+			return nil, err
+		}
+
+		var item models.OrderItem
+		err := tx.Where("order_id = ? AND product_id = ?", order.ID, product.ID).First(&item).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		if err == nil {
+			return os.changeItemQuantity(tx, order, &item, item.Jumlah+req.Jumlah)
+		}
+
+		jumlahBackorder, err := reserveStock(tx, &product, req.Jumlah)
+		if err != nil {
+			return nil, err
+		}
+
+		harga, err := productPrice(tx, order, &product)
+		if err != nil {
+			return nil, err
+		}
+
+		tarifPajak := config.GetTaxRate(product.Kategori)
+		subtotal, pajak := calculateTax(harga, req.Jumlah, tarifPajak, order.HargaTermasukPajak)
+
+		item = models.OrderItem{
+			OrderID:    order.ID,
+			ProductID:  product.ID,
+			Jumlah:     req.Jumlah,
+			Harga:      harga,
+			Subtotal:   subtotal,
+			TarifPajak: tarifPajak,
+			Pajak:      pajak,
+		}
+		setBackorder(&item, &product, jumlahBackorder)
+
+		if err := tx.Create(&item).Error; err != nil {
+			return nil, errors.New("error creating order item: " + err.Error())
+		}
+
+		return &models.OrderChange{
+			Aksi:          "item_added",
+			OrderItemID:   &item.ID,
+			ProductID:     &item.ProductID,
+			JumlahSebelum: 0,
+			JumlahSesudah: item.Jumlah,
+		}, nil
+	})
+}
+
+func (os *OrderService) UpdateOrderItem(orderID uint, itemID uint, userID uint, req *models.UpdateOrderItemRequest) (*models.Order, error) {
+	return os.editPendingOrder(orderID, userID, func(tx *gorm.DB, order *models.Order) (*models.OrderChange, error) {
+		var item models.OrderItem
+		if err := tx.Where("id = ? AND order_id = ?", itemID, order.ID).First(&item).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("order item not found")
+			}
+			return nil, err
+		}
+
+		return os.changeItemQuantity(tx, order, &item, req.Jumlah)
+	})
+}
+
+func (os *OrderService) RemoveOrderItem(orderID uint, itemID uint, userID uint) (*models.Order, error) {
+	return os.editPendingOrder(orderID, userID, func(tx *gorm.DB, order *models.Order) (*models.OrderChange, error) {
+		var item models.OrderItem
+		if err := tx.Where("id = ? AND order_id = ?", itemID, order.ID).First(&item).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("order item not found")
+			}
+			return nil, err
+		}
+
+		var count int64
+		if err := tx.Model(&models.OrderItem{}).Where("order_id = ?", order.ID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+
+		if count <= 1 {
+			return nil, errors.New("order must contain at least one item, cancel the order instead")
+		}
+
+		if err := tx.Delete(&item).Error; err != nil {
+			return nil, errors.New("error deleting order item: " + err.Error())
+		}
+
+		if err := allocateOrderBackorders(tx, []uint{item.ProductID}); err != nil {
+			return nil, err
+		}
+
+		return &models.OrderChange{
+			Aksi:          "item_removed",
+			OrderItemID:   &item.ID,
+			ProductID:     &item.ProductID,
+			JumlahSebelum: item.Jumlah,
+			JumlahSesudah: 0,
+		}, nil
+	})
+}
+
+func (os *OrderService) GetOrderChanges(orderID uint, userID uint) ([]models.OrderChange, error) {
+	if _, err := os.GetOrderByIDAndUserID(orderID, userID); err != nil {
+		return nil, err
+	}
+
+	var changes []models.OrderChange
+	if err := os.DB.Where("order_id = ?", orderID).Order("created_at ASC").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, errors.New("no order changes found")
+	}
+
+	return changes, nil
+}
+
+func (os *OrderService) changeItemQuantity(tx *gorm.DB, order *models.Order, item *models.OrderItem, jumlah int) (*models.OrderChange, error) {
+	if jumlah == item.Jumlah {
+		return nil, errors.New("quantity is unchanged")
+	}
+
+	var product models.Product
+	if err := tx.First(&product, item.ProductID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("product is no longer available")
+		}
+		return nil, err
+	}
+
+	// Added units queue behind earlier orders; removed units come off the backorder first.
+	jumlahBackorder := max(item.JumlahBackorder-(item.Jumlah-jumlah), 0)
+	if jumlah > item.Jumlah {
+		tambahan, err := reserveStock(tx, &product, jumlah-item.Jumlah)
+		if err != nil {
+			return nil, err
+		}
+		jumlahBackorder = item.JumlahBackorder + tambahan
+	}
+
+	jumlahSebelum := item.Jumlah
+	item.Jumlah = jumlah
+	item.Subtotal, item.Pajak = calculateTax(item.Harga, jumlah, item.TarifPajak, order.HargaTermasukPajak)
+	setBackorder(item, &product, jumlahBackorder)
+
+	err := tx.Model(item).Select("jumlah", "subtotal", "pajak", "jumlah_backorder", "jenis_backorder", "tersedia_pada").Updates(item).Error
+	if err != nil {
+		return nil, errors.New("error updating order item: " + err.Error())
+	}
+
+	if jumlah < jumlahSebelum {
+		if err := allocateOrderBackorders(tx, []uint{item.ProductID}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.OrderChange{
+		Aksi:          "quantity_changed",
+		OrderItemID:   &item.ID,
+		ProductID:     &item.ProductID,
+		JumlahSebelum: jumlahSebelum,
+		JumlahSesudah: jumlah,
+	}, nil
+}
+
+func (os *OrderService) editPendingOrder(orderID uint, userID uint, edit func(tx *gorm.DB, order *models.Order) (*models.OrderChange, error)) (*models.Order, error) {
+	tx := os.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	if order.Status != "pending" {
+		tx.Rollback()
+		return nil, errors.New("only pending orders can be edited")
+	}
+
+	totalSebelum := order.TotalHarga
+
+	change, err := edit(tx, &order)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := os.recalculateOrder(tx, &order); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := bumpVersion(tx, &order); err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating order version: " + err.Error())
+	}
+
+	// Payments started for the old total can no longer confirm the order.
+	err = tx.Model(&models.Payment{}).Where("order_id = ? AND status = ?", order.ID, "pending").Update("status", "expired").Error
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.New("error expiring payments: " + err.Error())
+	}
+
+	change.OrderID = order.ID
+	change.UserID = &userID
+	change.TotalSebelum = totalSebelum
+	change.TotalSesudah = order.TotalHarga
+
+	if err := tx.Create(change).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error recording order change: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return os.GetOrderByID(order.ID)
+}
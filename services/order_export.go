@@ -0,0 +1,143 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"golang-api/models"
+	"golang-api/utils"
+	"io"
+	"strings"
+	"time"
+)
+
+type orderExportWriter interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+type csvOrderExportWriter struct {
+	writer *csv.Writer
+}
+
+func (cw *csvOrderExportWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		if text, ok := value.(string); ok {
+			record[i] = escapeCSVFormula(text)
+		} else {
+			record[i] = fmt.Sprint(value)
+		}
+	}
+
+	return cw.writer.Write(record)
+}
+
+// escapeCSVFormula prefixes text that a spreadsheet would run as a formula with a quote, so names
+// entered by customers are shown as text when the export is opened in Excel.
+func escapeCSVFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+
+	return text
+}
+
+func (cw *csvOrderExportWriter) Close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+type xlsxOrderExportWriter struct {
+	writer *utils.XLSXWriter
+}
+
+func (xw *xlsxOrderExportWriter) WriteRow(values []any) error {
+	for i, value := range values {
+		if money, ok := value.(models.Money); ok {
+			values[i] = money.Float64()
+		}
+	}
+
+	return xw.writer.WriteRow(values)
+}
+
+func (xw *xlsxOrderExportWriter) Close() error {
+	return xw.writer.Close()
+}
+
+var orderExportColumns = []any{
+	"order_id", "nomor_order", "tanggal_order", "pelanggan", "email", "product_id", "produk",
+	"jumlah", "harga", "subtotal", "mata_uang", "status",
+}
+
+// ExportOrders writes one row per order item of the orders matching the filters as CSV or XLSX.
+// Rows are read from the database cursor and written one by one, so the export never holds the
+// whole result in memory. Nothing is written to w when the query fails.
+func (os *OrderService) ExportOrders(req *models.ExportOrderRequest, w io.Writer) error {
+	orders := os.filterOrders(os.DB.Model(&models.Order{}), &req.OrderFilter).Select("id")
+
+	// SortBy and SortOrder are restricted to known columns by the request binding.
+	orderBy := "orders." + req.SortBy + " " + strings.ToUpper(req.SortOrder) + ", orders.id, order_items.id"
+
+	rows, err := os.DB.Table("order_items").
+		Select("orders.id, orders.nomor_order, orders.tanggal_order, users.name, users.email, order_items.product_id, "+
+			"products.nama, order_items.jumlah, order_items.harga, order_items.subtotal, orders.mata_uang, orders.status").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("LEFT JOIN users ON users.id = orders.user_id").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Where("order_items.deleted_at IS NULL AND order_items.order_id IN (?)", orders).
+		Order(orderBy).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var writer orderExportWriter
+	if req.Format == "xlsx" {
+		xlsxWriter, err := utils.NewXLSXWriter(w, "Orders")
+		if err != nil {
+			return err
+		}
+		writer = &xlsxOrderExportWriter{writer: xlsxWriter}
+	} else {
+		writer = &csvOrderExportWriter{writer: csv.NewWriter(w)}
+	}
+
+	if err := writer.WriteRow(append([]any{}, orderExportColumns...)); err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var (
+			orderID, productID   uint
+			nomorOrder, mataUang sql.NullString
+			tanggalOrder         time.Time
+			nama, email, produk  sql.NullString
+			jumlah               int
+			harga, subtotal      models.Money
+			status               string
+		)
+
+		err := rows.Scan(&orderID, &nomorOrder, &tanggalOrder, &nama, &email, &productID, &produk,
+			&jumlah, &harga, &subtotal, &mataUang, &status)
+		if err != nil {
+			return err
+		}
+
+		err = writer.WriteRow([]any{
+			orderID, nomorOrder.String, tanggalOrder.Format("2006-01-02 15:04:05"), nama.String, email.String,
+			productID, produk.String, jumlah, harga, subtotal, mataUang.String, status,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
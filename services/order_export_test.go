@@ -0,0 +1,25 @@
+package services
+
+import "testing"
+
+func TestEscapeCSVFormula(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "", want: ""},
+		{text: "Budi", want: "Budi"},
+		{text: "=HYPERLINK(\"http://x\")", want: "'=HYPERLINK(\"http://x\")"},
+		{text: "+62 812", want: "'+62 812"},
+		{text: "-1", want: "'-1"},
+		{text: "@SUM(A1)", want: "'@SUM(A1)"},
+		{text: "\tcmd", want: "'\tcmd"},
+		{text: "a=b", want: "a=b"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeCSVFormula(tt.text); got != tt.want {
+			t.Errorf("escapeCSVFormula(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
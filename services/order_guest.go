@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"golang-api/utils"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GuestCheckout creates an order for a customer without an account. The email is kept as a user
+// with the guest role, which cannot log in until it is claimed through the link sent by Register.
+// The name, phone and address are only kept on the order: the checkout does not prove who owns the
+// email, so nothing is added to the address book the guest gets once claimed.
+func (os *OrderService) GuestCheckout(req *models.GuestCheckoutRequest) (*models.Order, error) {
+	tx := os.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	user, err := guestUser(tx, req.Nama, req.Email)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	order, err := os.createOrder(tx, user.ID, &models.CreateOrderRequest{
+		Items: req.Items,
+		AlamatPengiriman: &models.ShippingAddress{
+			NamaPenerima: req.Nama,
+			Telepon:      req.Telepon,
+			Alamat:       req.Alamat,
+			Kota:         req.Kota,
+			Provinsi:     req.Provinsi,
+			KodePos:      req.KodePos,
+		},
+		MetodePengiriman: req.MetodePengiriman,
+		MataUang:         req.MataUang,
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	if err := os.DB.Preload("User").Preload("OrderItems.Product").First(order, order.ID).Error; err != nil {
+		return nil, errors.New("error loading order with relations")
+	}
+
+	return order, nil
+}
+
+// GuestOrderLink returns the signed link to follow an order without logging in and when it expires.
+func (os *OrderService) GuestOrderLink(nomorOrder string) (string, time.Time) {
+	berlakuSampai := time.Now().Add(config.GetGuestOrderLinkTTL())
+
+	return guestOrderLink(nomorOrder, berlakuSampai), berlakuSampai
+}
+
+func (os *OrderService) GetGuestOrder(nomorOrder string, req *models.GuestOrderLinkRequest) (*models.Order, error) {
+	if err := verifyGuestOrderLink(nomorOrder, req); err != nil {
+		return nil, err
+	}
+
+	return os.GetOrderByNumber(nomorOrder)
+}
+
+// guestUser returns the guest user with the email, creating it on the first checkout. Emails of
+// registered users are refused, their orders belong in their account. The details of an existing
+// guest are left as they are, the checkout does not prove it owns the email; the name of the
+// checkout is kept in the shipping address.
+func guestUser(tx *gorm.DB, nama string, email string) (*models.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	var user models.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("email = ?", email).First(&user).Error
+	if err == nil {
+		if user.Role != "guest" {
+			return nil, errors.New("email is already registered, log in to place the order")
+		}
+
+		return &user, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user = models.User{
+		Name:  nama,
+		Email: email,
+		Role:  "guest",
+	}
+
+	if err := tx.Create(&user).Error; err != nil {
+		return nil, errors.New("error creating guest: " + err.Error())
+	}
+
+	return &user, nil
+}
+
+func guestOrderLink(nomorOrder string, berlakuSampai time.Time) string {
+	expires := berlakuSampai.Unix()
+	signature := utils.SignPayload(config.GetGuestOrderLinkSecret(), guestOrderLinkPayload(nomorOrder, expires))
+
+	return fmt.Sprintf("%s/api/guest/orders/%s?expires=%d&signature=%s", config.GetAppBaseURL(), url.PathEscape(nomorOrder), expires, signature)
+}
+
+func verifyGuestOrderLink(nomorOrder string, req *models.GuestOrderLinkRequest) error {
+	payload := guestOrderLinkPayload(nomorOrder, req.Expires)
+	if !utils.VerifyPayloadSignature(config.GetGuestOrderLinkSecret(), payload, req.Signature) {
+		return errors.New("invalid order link")
+	}
+
+	if time.Now().Unix() > req.Expires {
+		return errors.New("order link has expired")
+	}
+
+	return nil
+}
+
+func guestOrderLinkPayload(nomorOrder string, expires int64) []byte {
+	return []byte(fmt.Sprintf("guest-order:%s:%d", nomorOrder, expires))
+}
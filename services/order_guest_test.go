@@ -0,0 +1,71 @@
+package services
+
+import (
+	"golang-api/models"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyGuestOrderLink(t *testing.T) {
+	t.Setenv("GUEST_ORDER_LINK_SECRET", "secret")
+
+	link, err := url.Parse(guestOrderLink("ORD-20240305-000042", time.Now().Add(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires, _ := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	signature := link.Query().Get("signature")
+
+	expired := time.Now().Add(-time.Hour)
+	expiredLink, _ := url.Parse(guestOrderLink("ORD-20240305-000042", expired))
+
+	tests := []struct {
+		name       string
+		nomorOrder string
+		req        models.GuestOrderLinkRequest
+		wantErr    string
+	}{
+		{name: "valid", nomorOrder: "ORD-20240305-000042", req: models.GuestOrderLinkRequest{Expires: expires, Signature: signature}},
+		{name: "other order", nomorOrder: "ORD-20240305-000043", req: models.GuestOrderLinkRequest{Expires: expires, Signature: signature}, wantErr: "invalid order link"},
+		{name: "extended expiry", nomorOrder: "ORD-20240305-000042", req: models.GuestOrderLinkRequest{Expires: expires + 3600, Signature: signature}, wantErr: "invalid order link"},
+		{name: "expired", nomorOrder: "ORD-20240305-000042", req: models.GuestOrderLinkRequest{Expires: expired.Unix(), Signature: expiredLink.Query().Get("signature")}, wantErr: "order link has expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyGuestOrderLink(tt.nomorOrder, &tt.req)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("verifyGuestOrderLink() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("verifyGuestOrderLink() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyGuestClaimLink(t *testing.T) {
+	t.Setenv("GUEST_ORDER_LINK_SECRET", "secret")
+
+	guest := &models.User{Email: "guest@example.com"}
+	guest.ID = 7
+
+	link, err := url.Parse(guestClaimLink(guest, time.Now().Add(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := models.GuestClaimLinkRequest{Email: link.Query().Get("email"), Signature: link.Query().Get("signature")}
+	req.Expires, _ = strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+
+	if err := verifyGuestClaimLink(guest, &req); err != nil {
+		t.Fatalf("verifyGuestClaimLink() error = %v, want nil", err)
+	}
+
+	other := &models.User{Email: "guest@example.com"}
+	other.ID = 8
+	if err := verifyGuestClaimLink(other, &req); err == nil || err.Error() != "invalid claim link" {
+		t.Fatalf("verifyGuestClaimLink() for another guest error = %v, want invalid claim link", err)
+	}
+}
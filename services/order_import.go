@@ -0,0 +1,298 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"io"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type orderImportGroup struct {
+	order   models.ImportedOrder
+	userID  uint
+	items   []models.CreateOrderItemRequest
+	invalid bool
+}
+
+// ImportOrders creates orders from a CSV with the columns email, product_id or sku, jumlah and an
+// optional referensi. Rows with the same email and referensi form one order. Every order is
+// created with the same logic as CreateOrder: in all_or_nothing mode all orders share one
+// transaction and any error rolls back every order, in per_order mode every order has its own
+// transaction and only the failing orders are skipped. A dry run reports the result and rolls
+// everything back. Files with more than ORDER_IMPORT_MAX_ROWS rows are refused.
+func (os *OrderService) ImportOrders(r io.Reader, req *models.ImportOrderRequest) (*models.ImportOrderResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	maxRows := config.GetOrderImportMaxRows()
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("error reading order import file: " + err.Error())
+		}
+
+		if len(records) > maxRows {
+			return nil, fmt.Errorf("order import file has more than %d rows", maxRows)
+		}
+
+		records = append(records, record)
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("order import file is empty")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["email"]; !ok {
+		return nil, errors.New("order import file requires an email column")
+	}
+	if _, ok := columns["jumlah"]; !ok {
+		return nil, errors.New("order import file requires a jumlah column")
+	}
+	_, hasProductID := columns["product_id"]
+	_, hasSKU := columns["sku"]
+	if !hasProductID && !hasSKU {
+		return nil, errors.New("order import file requires a product_id or sku column")
+	}
+
+	result := &models.ImportOrderResult{
+		DryRun: req.DryRun,
+		Mode:   req.Mode,
+		Orders: []models.ImportedOrder{},
+		Errors: []models.ImportOrderRowError{},
+	}
+
+	groups := map[string]*orderImportGroup{}
+	var groupOrder []*orderImportGroup
+	users := map[string]uint{}
+
+	for i, record := range records[1:] {
+		baris := i + 2
+
+		field := func(name string) string {
+			if index, ok := columns[name]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		result.TotalBaris++
+
+		email := strings.ToLower(field("email"))
+		referensi := field("referensi")
+
+		key := email + "\x00" + referensi
+		group, ok := groups[key]
+		if !ok {
+			group = &orderImportGroup{order: models.ImportedOrder{Email: email, Referensi: referensi}}
+			groups[key] = group
+			groupOrder = append(groupOrder, group)
+		}
+		group.order.Baris = append(group.order.Baris, baris)
+
+		item, userID, err := os.parseImportRow(email, field("product_id"), field("sku"), field("jumlah"), users)
+		if err != nil {
+			result.Errors = append(result.Errors, models.ImportOrderRowError{Baris: baris, Pesan: err.Error()})
+			group.invalid = true
+			continue
+		}
+
+		group.userID = userID
+		group.items = mergeImportItem(group.items, item)
+	}
+
+	if result.TotalBaris == 0 {
+		return nil, errors.New("order import file is empty")
+	}
+
+	if req.Mode == "per_order" && !req.DryRun {
+		return os.importOrdersPerOrder(groupOrder, result), nil
+	}
+
+	tx := os.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for i, group := range groupOrder {
+		if group.invalid {
+			continue
+		}
+
+		savePoint := fmt.Sprintf("import_order_%d", i)
+		if err := tx.SavePoint(savePoint).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		order, err := os.createOrder(tx, group.userID, &models.CreateOrderRequest{Items: group.items})
+		if err != nil {
+			if err := tx.RollbackTo(savePoint).Error; err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+
+			addImportOrderError(result, group, err)
+			continue
+		}
+
+		addImportedOrder(result, group, order)
+	}
+
+	if req.DryRun || (req.Mode == "all_or_nothing" && len(result.Errors) > 0) {
+		tx.Rollback()
+
+		for i := range result.Orders {
+			result.Orders[i].OrderID = 0
+			result.Orders[i].NomorOrder = ""
+		}
+
+		return result, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	result.OrderDibuat = len(result.Orders)
+
+	return result, nil
+}
+
+// importOrdersPerOrder creates every order in its own transaction, so the order number sequence and
+// the stock rows are only locked while one order is created, and a deadlock or lock wait timeout
+// only fails the order it happened in.
+func (os *OrderService) importOrdersPerOrder(groups []*orderImportGroup, result *models.ImportOrderResult) *models.ImportOrderResult {
+	for _, group := range groups {
+		if group.invalid {
+			continue
+		}
+
+		tx := os.DB.Begin()
+
+		order, err := os.createOrder(tx, group.userID, &models.CreateOrderRequest{Items: group.items})
+		if err != nil {
+			tx.Rollback()
+			addImportOrderError(result, group, err)
+			continue
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			addImportOrderError(result, group, errors.New("error committing transaction: "+err.Error()))
+			continue
+		}
+
+		addImportedOrder(result, group, order)
+	}
+
+	result.OrderDibuat = len(result.Orders)
+
+	return result
+}
+
+func addImportedOrder(result *models.ImportOrderResult, group *orderImportGroup, order *models.Order) {
+	group.order.OrderID = order.ID
+	group.order.NomorOrder = order.NomorOrder
+	group.order.MataUang = order.MataUang
+	group.order.TotalHarga = order.TotalHarga
+	result.Orders = append(result.Orders, group.order)
+}
+
+func addImportOrderError(result *models.ImportOrderResult, group *orderImportGroup, err error) {
+	for _, baris := range group.order.Baris {
+		result.Errors = append(result.Errors, models.ImportOrderRowError{
+			Baris: baris,
+			Pesan: "order could not be created: " + err.Error(),
+		})
+	}
+}
+
+func (os *OrderService) parseImportRow(email, productID, sku, jumlah string, users map[string]uint) (models.CreateOrderItemRequest, uint, error) {
+	var item models.CreateOrderItemRequest
+
+	if email == "" {
+		return item, 0, errors.New("email is required")
+	}
+
+	userID, ok := users[email]
+	if !ok {
+		var user models.User
+		if err := os.DB.Where("email = ?", email).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return item, 0, errors.New("customer not found: " + email)
+			}
+			return item, 0, err
+		}
+
+		userID = user.ID
+		users[email] = userID
+	}
+
+	var product models.Product
+	switch {
+	case productID != "":
+		id, err := strconv.ParseUint(productID, 10, 64)
+		if err != nil {
+			return item, 0, errors.New("invalid product_id: " + productID)
+		}
+
+		if err := os.DB.First(&product, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return item, 0, errors.New("product not found: " + productID)
+			}
+			return item, 0, err
+		}
+	case sku != "":
+		if err := os.DB.Where("sku = ?", sku).First(&product).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return item, 0, errors.New("product not found: " + sku)
+			}
+			return item, 0, err
+		}
+	default:
+		return item, 0, errors.New("product_id or sku is required")
+	}
+
+	n, err := strconv.Atoi(jumlah)
+	if err != nil || n < 1 {
+		return item, 0, errors.New("jumlah must be a positive number")
+	}
+
+	item.ProductID = product.ID
+	item.Jumlah = n
+
+	return item, userID, nil
+}
+
+func mergeImportItem(items []models.CreateOrderItemRequest, item models.CreateOrderItemRequest) []models.CreateOrderItemRequest {
+	for i := range items {
+		if items[i].ProductID == item.ProductID {
+			items[i].Jumlah += item.Jumlah
+			return items
+		}
+	}
+
+	return append(items, item)
+}
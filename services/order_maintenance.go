@@ -0,0 +1,24 @@
+package services
+
+import (
+	"golang-api/config"
+	"log"
+	"time"
+)
+
+// RunOrderMaintenance archives old delivered orders and purges expired soft-deleted orders.
+func (os *OrderService) RunOrderMaintenance() {
+	archived, err := os.ArchiveOrders(time.Now().Add(-config.GetOrderArchiveAfter()))
+	if err != nil {
+		log.Println("Error archiving orders: " + err.Error())
+	} else if archived > 0 {
+		log.Printf("Archived %d orders", archived)
+	}
+
+	purged, err := os.PurgeDeletedOrders(time.Now().Add(-config.GetOrderDeletedRetention()))
+	if err != nil {
+		log.Println("Error purging deleted orders: " + err.Error())
+	} else if purged > 0 {
+		log.Printf("Purged %d deleted orders", purged)
+	}
+}
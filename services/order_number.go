@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var orderNumberSeqPattern = regexp.MustCompile(`\{SEQ(?::(\d+))?\}`)
+
+func formatOrderDate(format string, t time.Time) string {
+	return strings.NewReplacer(
+		"{YYYY}", t.Format("2006"),
+		"{YY}", t.Format("06"),
+		"{MM}", t.Format("01"),
+		"{DD}", t.Format("02"),
+	).Replace(format)
+}
+
+func formatOrderNumber(format string, t time.Time, seq uint64) string {
+	if !orderNumberSeqPattern.MatchString(format) {
+		format += "-{SEQ:6}"
+	}
+
+	return orderNumberSeqPattern.ReplaceAllStringFunc(formatOrderDate(format, t), func(token string) string {
+		width := 0
+		if match := orderNumberSeqPattern.FindStringSubmatch(token); match[1] != "" {
+			width, _ = strconv.Atoi(match[1])
+		}
+		return fmt.Sprintf("%0*d", width, seq)
+	})
+}
+
+func generateOrderNumber(tx *gorm.DB, t time.Time) (string, error) {
+	format := config.GetOrderNumberFormat()
+	scope := orderNumberSeqPattern.ReplaceAllString(formatOrderDate(format, t), "")
+
+	seq, err := nextSequence(tx, "order:"+scope)
+	if err != nil {
+		return "", err
+	}
+
+	return formatOrderNumber(format, t, seq), nil
+}
+
+func BackfillOrderNumbers(db *gorm.DB) error {
+	var orders []models.Order
+	if err := db.Unscoped().Where("nomor_order IS NULL OR nomor_order = ?", "").Order("id ASC").Find(&orders).Error; err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		tx := db.Begin()
+
+		nomorOrder, err := generateOrderNumber(tx, order.TanggalOrder)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&models.Order{}).Where("id = ?", order.ID).Update("nomor_order", nomorOrder).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatOrderNumber(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		format string
+		seq    uint64
+		want   string
+	}{
+		{name: "padded sequence", format: "ORD-{YYYY}{MM}{DD}-{SEQ:6}", seq: 42, want: "ORD-20240305-000042"},
+		{name: "short year", format: "INV/{YY}/{MM}/{SEQ:4}", seq: 7, want: "INV/24/03/0007"},
+		{name: "unpadded sequence", format: "{YYYY}-{SEQ}", seq: 123, want: "2024-123"},
+		{name: "sequence wider than padding", format: "{SEQ:2}", seq: 12345, want: "12345"},
+		{name: "sequence appended when missing", format: "ORD-{YYYY}", seq: 3, want: "ORD-2024-000003"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatOrderNumber(tt.format, date, tt.seq); got != tt.want {
+				t.Errorf("formatOrderNumber(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
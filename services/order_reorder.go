@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+	"golang-api/models"
+
+	"gorm.io/gorm"
+)
+
+// Reorder creates a new order from the items of one of the customer's orders at the current
+// prices. Deleted products and products without stock are dropped and quantities are lowered to
+// the available stock; every difference with the original order is returned as an adjustment.
+func (os *OrderService) Reorder(orderID uint, userID uint) (*models.Order, []models.ReorderAdjustment, error) {
+	var source models.Order
+	err := os.DB.Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("id = ? AND user_id = ?", orderID, userID).First(&source).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("order not found")
+		}
+		return nil, nil, err
+	}
+
+	req := models.CreateOrderRequest{
+		MetodePengiriman: source.MetodePengiriman,
+		MataUang:         source.MataUang,
+	}
+
+	// Fall back to the default address and shipping method when the old ones are gone.
+	if source.AddressID != nil {
+		if _, err := os.resolveShippingAddress(os.DB, userID, *source.AddressID); err == nil {
+			req.AddressID = *source.AddressID
+		}
+	}
+
+	if _, err := os.getShippingCalculator(req.MetodePengiriman); err != nil {
+		req.MetodePengiriman = ""
+	}
+
+	var adjustments []models.ReorderAdjustment
+	hargaSebelum := map[uint]models.Money{}
+
+	for _, item := range source.OrderItems {
+		product := item.Product
+		hargaSebelum[product.ID] = item.Harga
+
+		if product.DeletedAt.Valid {
+			adjustments = append(adjustments, models.ReorderAdjustment{
+				ProductID:     item.ProductID,
+				Nama:          product.Nama,
+				Alasan:        "product_unavailable",
+				JumlahSebelum: item.Jumlah,
+				JumlahSesudah: 0,
+			})
+			continue
+		}
+
+		jumlah := item.Jumlah
+		if product.ModeStok != "backorder" && product.ModeStok != "preorder" {
+			available, err := availableStock(os.DB, product.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if available < jumlah {
+				jumlah = max(available, 0)
+				adjustments = append(adjustments, models.ReorderAdjustment{
+					ProductID:     item.ProductID,
+					Nama:          product.Nama,
+					Alasan:        "insufficient_stock",
+					JumlahSebelum: item.Jumlah,
+					JumlahSesudah: jumlah,
+				})
+			}
+		}
+
+		if jumlah > 0 {
+			req.Items = append(req.Items, models.CreateOrderItemRequest{
+				ProductID: product.ID,
+				Jumlah:    jumlah,
+			})
+		}
+	}
+
+	if len(req.Items) == 0 {
+		return nil, adjustments, errors.New("none of the items of this order are available anymore")
+	}
+
+	order, err := os.CreateOrder(userID, &req)
+	if err != nil {
+		return nil, adjustments, err
+	}
+
+	for _, item := range order.OrderItems {
+		if harga := hargaSebelum[item.ProductID]; harga != item.Harga {
+			adjustments = append(adjustments, models.ReorderAdjustment{
+				ProductID:     item.ProductID,
+				Nama:          item.Product.Nama,
+				Alasan:        "price_changed",
+				JumlahSebelum: item.Jumlah,
+				JumlahSesudah: item.Jumlah,
+				HargaSebelum:  harga,
+				HargaSesudah:  item.Harga,
+			})
+		}
+	}
+
+	return order, adjustments, nil
+}
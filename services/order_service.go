@@ -2,18 +2,26 @@ package services
 
 import (
 	"errors"
+	"golang-api/config"
 	"golang-api/models"
+	"slices"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderService struct {
-	DB *gorm.DB
+	DB                  *gorm.DB
+	ShippingCalculators map[string]ShippingCalculator
 }
 
 func NewOrderService(db *gorm.DB) *OrderService {
-	return &OrderService{DB: db}
+	return &OrderService{
+		DB:                  db,
+		ShippingCalculators: NewShippingCalculators(),
+	}
 }
 
 func (os *OrderService) CreateOrder(userID uint, req *models.CreateOrderRequest) (*models.Order, error) {
@@ -24,99 +32,315 @@ func (os *OrderService) CreateOrder(userID uint, req *models.CreateOrderRequest)
 		}
 	}()
 
+	order, err := os.createOrder(tx, userID, req)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	if err := os.DB.Preload("User").Preload("OrderItems.Product").First(order, order.ID).Error; err != nil {
+		return nil, errors.New("error loading order with relations")
+	}
+
+	return order, nil
+}
+
+// createOrder creates an order with its items inside the caller's transaction.
+func (os *OrderService) createOrder(tx *gorm.DB, userID uint, req *models.CreateOrderRequest) (*models.Order, error) {
 	var user models.User
 	if err := tx.First(&user, userID).Error; err != nil {
-		tx.Rollback()
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("user not found")
 		}
 		return nil, err
 	}
 
+	var addressID *uint
+	alamatPengiriman := req.AlamatPengiriman
+	if alamatPengiriman == nil {
+		address, err := os.resolveShippingAddress(tx, userID, req.AddressID)
+		if err != nil {
+			return nil, err
+		}
+
+		shippingAddress := address.ToShippingAddress()
+		addressID = &address.ID
+		alamatPengiriman = &shippingAddress
+	}
+
+	metodePengiriman := req.MetodePengiriman
+	if metodePengiriman == "" {
+		metodePengiriman = config.GetShippingDefaultMethod()
+	}
+
+	calculator, err := os.getShippingCalculator(metodePengiriman)
+	if err != nil {
+		return nil, err
+	}
+
+	mataUang := req.MataUang
+	if mataUang == "" {
+		mataUang = models.BaseCurrency
+	}
+
+	mataUang, err = normalizeCurrency(mataUang)
+	if err != nil {
+		return nil, err
+	}
+
+	kurs, err := getExchangeRate(tx, mataUang)
+	if err != nil {
+		return nil, err
+	}
+
+	inclusive := config.IsTaxInclusive()
+	tanggalOrder := time.Now()
+
+	nomorOrder, err := generateOrderNumber(tx, tanggalOrder)
+	if err != nil {
+		return nil, errors.New("error generating order number: " + err.Error())
+	}
+
 	order := models.Order{
-		UserID:       userID,
-		Status:       "pending",
-		TanggalOrder: time.Now(),
-		TotalHarga:   0,
+		NomorOrder:         nomorOrder,
+		UserID:             userID,
+		MataUang:           mataUang,
+		Kurs:               kurs,
+		Status:             "pending",
+		TanggalOrder:       tanggalOrder,
+		HargaTermasukPajak: inclusive,
+		AddressID:          addressID,
+		AlamatPengiriman:   *alamatPengiriman,
+		MetodePengiriman:   calculator.Name(),
+		TotalHarga:         0,
 	}
 
 	if err := tx.Create(&order).Error; err != nil {
-		tx.Rollback()
 		return nil, errors.New("error creating order: " + err.Error())
 	}
 
-	var totalHarga float64
-	var orderItems []models.OrderItem
-
 	for _, item := range req.Items {
 		var product models.Product
 		if err := tx.First(&product, item.ProductID).Error; err != nil {
-			tx.Rollback()
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, errors.New("product not found")
 			}
 			return nil, err
 		}
 
-		subtotal := product.Harga * float64(item.Jumlah)
+		jumlahBackorder, err := reserveStock(tx, &product, item.Jumlah)
+		if err != nil {
+			return nil, err
+		}
+
+		harga, err := productPrice(tx, &order, &product)
+		if err != nil {
+			return nil, err
+		}
+
+		tarifPajak := config.GetTaxRate(product.Kategori)
+		subtotal, pajak := calculateTax(harga, item.Jumlah, tarifPajak, inclusive)
 
 		orderItem := models.OrderItem{
-			OrderID:   order.ID,
-			ProductID: item.ProductID,
-			Jumlah:    item.Jumlah,
-			Harga:     product.Harga,
-			Subtotal:  subtotal,
+			OrderID:    order.ID,
+			ProductID:  item.ProductID,
+			Jumlah:     item.Jumlah,
+			Harga:      harga,
+			Subtotal:   subtotal,
+			TarifPajak: tarifPajak,
+			Pajak:      pajak,
 		}
+		setBackorder(&orderItem, &product, jumlahBackorder)
 
 		if err := tx.Create(&orderItem).Error; err != nil {
-			tx.Rollback()
 			return nil, errors.New("error creating order item: " + err.Error())
 		}
+	}
 
-		orderItems = append(orderItems, orderItem)
-		totalHarga += subtotal
+	if err := os.recalculateOrder(tx, &order); err != nil {
+		return nil, err
 	}
 
-	order.TotalHarga = totalHarga
-	if err := tx.Save(&order).Error; err != nil {
-		tx.Rollback()
-		return nil, errors.New("error updating order total: " + err.Error())
+	if err := recordOrderEvent(tx, models.WebhookEventOrderCreated, &order, ""); err != nil {
+		return nil, err
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return nil, errors.New("error committing transaction: " + err.Error())
+	return &order, nil
+}
+
+// recordOrderEvent queues the webhook event and the customer email of an order change in the
+// caller's transaction.
+func recordOrderEvent(tx *gorm.DB, event string, order *models.Order, statusSebelum string) error {
+	if err := publishOrderEvent(tx, event, order, statusSebelum); err != nil {
+		return err
 	}
 
-	if err := os.DB.Preload("User").Preload("OrderItems.Product").First(&order, order.ID).Error; err != nil {
-		return nil, errors.New("error loading order with relations")
+	queueOrderNotification(tx, event, order, statusSebelum)
+
+	return nil
+}
+
+func (os *OrderService) getShippingCalculator(metodePengiriman string) (ShippingCalculator, error) {
+	calculator, ok := os.ShippingCalculators[metodePengiriman]
+	if !ok {
+		return nil, errors.New("unsupported shipping method: " + metodePengiriman)
 	}
 
-	return &order, nil
+	return calculator, nil
 }
 
-func (os *OrderService) GetOrders(req *models.GetOrderRequest) ([]models.Order, error) {
-	var orders []models.Order
+func (os *OrderService) recalculateOrder(tx *gorm.DB, order *models.Order) error {
+	calculator, err := os.getShippingCalculator(order.MetodePengiriman)
+	if err != nil {
+		return err
+	}
+
+	var items []models.OrderItem
+	err = tx.Preload("Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("order_id = ?", order.ID).Find(&items).Error
+	if err != nil {
+		return err
+	}
 
-	query := os.DB.Preload("User").Preload("OrderItems.Product")
+	var orderSubtotal, totalPajak models.Money
+	var beratTotal int
 
-	if req.Status != "" {
-		query = query.Where("status = ?", req.Status)
+	for _, item := range items {
+		orderSubtotal = orderSubtotal.Add(item.Subtotal)
+		totalPajak = totalPajak.Add(item.Pajak)
+		beratTotal += item.Product.Berat * item.Jumlah
 	}
 
-	if req.UserID != 0 {
-		query = query.Where("user_id = ?", req.UserID)
+	order.Subtotal = orderSubtotal
+	order.TotalPajak = totalPajak
+	order.BeratTotal = beratTotal
+
+	// Shipping rates are configured in the base currency.
+	ongkosKirim, err := calculator.Calculate(ShippingQuote{
+		Subtotal:   convertMoney(order.Subtotal.Add(order.TotalPajak), order.Kurs, 1),
+		BeratTotal: beratTotal,
+		Alamat:     order.AlamatPengiriman,
+	})
+	if err != nil {
+		return errors.New("error calculating shipping cost: " + err.Error())
 	}
 
-	err := query.Order("created_at DESC").Limit(req.Limit).Offset(req.Offset).Find(&orders).Error
+	order.OngkosKirim = convertMoney(ongkosKirim, 1, order.Kurs)
+	order.TotalHarga = order.Subtotal.Add(order.TotalPajak).Add(order.OngkosKirim)
+	order.TotalBersih = order.TotalHarga.Sub(order.TotalRefund)
+
+	err = tx.Model(order).Select("subtotal", "total_pajak", "berat_total", "ongkos_kirim", "total_harga", "total_bersih").Updates(order).Error
 	if err != nil {
+		return errors.New("error updating order total: " + err.Error())
+	}
+
+	return nil
+}
+
+func (os *OrderService) resolveShippingAddress(tx *gorm.DB, userID uint, addressID uint) (*models.Address, error) {
+	var address models.Address
+
+	query := tx.Where("user_id = ?", userID)
+	if addressID != 0 {
+		query = query.Where("id = ?", addressID)
+	} else {
+		query = query.Order("is_default DESC, created_at DESC")
+	}
+
+	if err := query.First(&address).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if addressID != 0 {
+				return nil, errors.New("address not found")
+			}
+			return nil, errors.New("shipping address is required")
+		}
 		return nil, err
 	}
 
+	return &address, nil
+}
+
+func (os *OrderService) GetOrders(req *models.GetOrderRequest) ([]models.Order, int64, error) {
+	var orders []models.Order
+
+	query := os.filterOrders(os.DB.Model(&models.Order{}), &req.OrderFilter).Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// SortBy and SortOrder are restricted to known columns by the request binding.
+	orderBy := req.SortBy + " " + strings.ToUpper(req.SortOrder)
+	if req.SortBy != "created_at" {
+		orderBy += ", created_at DESC"
+	}
+
+	err := query.Preload("User").Preload("OrderItems.Product").Order(orderBy).Limit(req.Limit).Offset(req.Offset).Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
 	if len(orders) == 0 {
-		return nil, errors.New("no orders found")
+		return nil, 0, errors.New("no orders found")
+	}
+
+	return orders, total, nil
+}
+
+func (os *OrderService) filterOrders(query *gorm.DB, req *models.OrderFilter) *gorm.DB {
+	var statuses []string
+	for _, status := range req.Status {
+		for _, s := range strings.Split(status, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				statuses = append(statuses, s)
+			}
+		}
+	}
+
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	if req.NomorOrder != "" {
+		query = query.Where("nomor_order LIKE ?", "%"+req.NomorOrder+"%")
+	}
+
+	if req.UserID != 0 {
+		query = query.Where("user_id = ?", req.UserID)
+	}
+
+	if req.Email != "" {
+		query = query.Where("user_id IN (?)", os.DB.Model(&models.User{}).Select("id").Where("email LIKE ?", "%"+req.Email+"%"))
+	}
+
+	if req.ProductID != 0 {
+		query = query.Where("id IN (?)", os.DB.Model(&models.OrderItem{}).Select("order_id").Where("product_id = ?", req.ProductID))
+	}
+
+	if !req.TanggalDari.IsZero() {
+		query = query.Where("tanggal_order >= ?", req.TanggalDari)
 	}
 
-	return orders, nil
+	if !req.TanggalSampai.IsZero() {
+		query = query.Where("tanggal_order < ?", req.TanggalSampai.AddDate(0, 0, 1))
+	}
+
+	// Orders can be in different currencies, so totals are compared in IDR with the rate kept on each order.
+	if req.TotalMin != 0 {
+		query = query.Where("total_harga * kurs >= ?", req.TotalMin)
+	}
+
+	if req.TotalMax != 0 {
+		query = query.Where("total_harga * kurs <= ?", req.TotalMax)
+	}
+
+	return query
 }
 
 func (os *OrderService) GetOrderByID(id uint) (*models.Order, error) {
@@ -147,19 +371,78 @@ func (os *OrderService) GetOrderByIDAndUserID(id uint, userID uint) (*models.Ord
 	return &order, nil
 }
 
-func (os *OrderService) UpdateOrderStatus(id uint, status string) (*models.Order, error) {
+func (os *OrderService) GetOrderByNumber(nomorOrder string) (*models.Order, error) {
+	var order models.Order
+
+	err := os.DB.Preload("User").Preload("OrderItems.Product").Where("nomor_order = ?", nomorOrder).First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (os *OrderService) GetOrderByNumberAndUserID(nomorOrder string, userID uint) (*models.Order, error) {
 	var order models.Order
 
-	if err := os.DB.First(&order, id).Error; err != nil {
+	err := os.DB.Preload("User").Preload("OrderItems.Product").Where("nomor_order = ? AND user_id = ?", nomorOrder, userID).First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// orderStatusTransitions are the status changes staff may make by hand. Orders are confirmed by a
+// settled payment and move through the shipping statuses with their shipments, so only
+// cancellation is left.
+var orderStatusTransitions = map[string][]string{
+	"pending":   {"cancelled"},
+	"confirmed": {"cancelled"},
+}
+
+// UpdateOrderStatus changes the status only if the order is still at versi, the version the change is based on.
+func (os *OrderService) UpdateOrderStatus(id uint, actorID uint, req *models.UpdateOrderStatusRequest, versi uint) (*models.Order, error) {
+	tx := os.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+		tx.Rollback()
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("order not found")
 		}
 		return nil, err
 	}
 
-	order.Status = status
-	if err := os.DB.Save(&order).Error; err != nil {
-		return nil, errors.New("error updating order status: " + err.Error())
+	if order.Versi != versi {
+		tx.Rollback()
+		return nil, ErrVersionConflict
+	}
+
+	if !slices.Contains(orderStatusTransitions[order.Status], req.Status) {
+		tx.Rollback()
+		return nil, errors.New("order status cannot be changed from " + order.Status + " to " + req.Status)
+	}
+
+	// Cancelling goes through cancelOrder, which releases the reserved stock and pending payments.
+	if err := cancelOrder(tx, &order, &actorID, "cancelled_by_staff", req.Komentar); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
 	}
 
 	if err := os.DB.Preload("User").Preload("OrderItems.Product").First(&order, order.ID).Error; err != nil {
@@ -186,6 +469,12 @@ func (os *OrderService) DeleteOrder(id uint) error {
 		return err
 	}
 
+	productIDs, err := orderProductIDs(tx, order.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Where("order_id = ?", id).Delete(&models.OrderItem{}).Error; err != nil {
 		tx.Rollback()
 		return errors.New("error deleting order items: " + err.Error())
@@ -196,6 +485,11 @@ func (os *OrderService) DeleteOrder(id uint) error {
 		return errors.New("error deleting order: " + err.Error())
 	}
 
+	if err := allocateOrderBackorders(tx, productIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return errors.New("error committing transaction: " + err.Error())
 	}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"golang-api/config"
+	"golang-api/models"
+	"golang-api/utils"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type PaymentCharge struct {
+	Referensi  string
+	PaymentURL string
+}
+
+type PaymentGateway interface {
+	Name() string
+	CreateCharge(payment *models.Payment) (*PaymentCharge, error)
+	ParseWebhook(payload []byte, signature string) (*models.PaymentNotification, error)
+}
+
+type MockPaymentGateway struct {
+	Secret []byte
+}
+
+func NewMockPaymentGateway() *MockPaymentGateway {
+	return &MockPaymentGateway{Secret: config.GetPaymentWebhookSecret()}
+}
+
+func (m *MockPaymentGateway) Name() string {
+	return "mock"
+}
+
+func (m *MockPaymentGateway) CreateCharge(payment *models.Payment) (*PaymentCharge, error) {
+	referensi := "MOCK-" + strings.ToUpper(uuid.New().String())
+
+	return &PaymentCharge{
+		Referensi:  referensi,
+		PaymentURL: "/api/payments/mock/" + referensi + "/settle",
+	}, nil
+}
+
+func (m *MockPaymentGateway) ParseWebhook(payload []byte, signature string) (*models.PaymentNotification, error) {
+	if !utils.VerifyPayloadSignature(m.Secret, payload, signature) {
+		return nil, errors.New("invalid webhook signature")
+	}
+
+	var notification models.PaymentNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, errors.New("invalid webhook payload: " + err.Error())
+	}
+
+	if notification.Referensi == "" || notification.Status == "" {
+		return nil, errors.New("invalid webhook payload: referensi and status are required")
+	}
+
+	if !slices.Contains([]string{"settled", "failed", "expired"}, notification.Status) {
+		return nil, errors.New("invalid webhook payload: unknown status " + notification.Status)
+	}
+
+	return &notification, nil
+}
+
+func (m *MockPaymentGateway) SignedNotification(notification *models.PaymentNotification) ([]byte, string, error) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, utils.SignPayload(m.Secret, payload), nil
+}
+
+func NewPaymentGateways() map[string]PaymentGateway {
+	gateways := make(map[string]PaymentGateway)
+
+	if config.IsMockPaymentEnabled() {
+		mock := NewMockPaymentGateway()
+		gateways[mock.Name()] = mock
+	}
+
+	return gateways
+}
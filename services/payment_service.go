@@ -0,0 +1,261 @@
+package services
+
+import (
+	"errors"
+	"golang-api/models"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentService struct {
+	DB       *gorm.DB
+	Gateways map[string]PaymentGateway
+}
+
+func NewPaymentService(db *gorm.DB) *PaymentService {
+	return &PaymentService{
+		DB:       db,
+		Gateways: NewPaymentGateways(),
+	}
+}
+
+func (ps *PaymentService) GetGateway(name string) (PaymentGateway, error) {
+	gateway, ok := ps.Gateways[name]
+	if !ok {
+		return nil, errors.New("unsupported payment gateway: " + name)
+	}
+
+	return gateway, nil
+}
+
+func (ps *PaymentService) CreatePayment(orderID uint, userID uint, gatewayName string) (*models.Payment, error) {
+	gateway, err := ps.GetGateway(gatewayName)
+	if err != nil {
+		return nil, err
+	}
+
+	var order models.Order
+	if err := ps.DB.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	if order.Status != "pending" {
+		return nil, errors.New("only pending orders can be paid")
+	}
+
+	var existing models.Payment
+	err = ps.DB.Where("order_id = ? AND gateway = ? AND status = ?", order.ID, gateway.Name(), "pending").First(&existing).Error
+	if err == nil && existing.Nominal == order.TotalHarga {
+		return &existing, nil
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// A pending payment for an earlier total must not confirm the order once it settles.
+	err = ps.DB.Model(&models.Payment{}).
+		Where("order_id = ? AND status = ? AND nominal <> ?", order.ID, "pending", order.TotalHarga).
+		Update("status", "expired").Error
+	if err != nil {
+		return nil, errors.New("error expiring pending payments: " + err.Error())
+	}
+
+	payment := models.Payment{
+		OrderID:  order.ID,
+		Gateway:  gateway.Name(),
+		Nominal:  order.TotalHarga,
+		MataUang: order.MataUang,
+		Status:   "pending",
+	}
+
+	charge, err := gateway.CreateCharge(&payment)
+	if err != nil {
+		return nil, errors.New("error creating payment charge: " + err.Error())
+	}
+
+	payment.Referensi = charge.Referensi
+	payment.PaymentURL = charge.PaymentURL
+
+	if err := ps.DB.Create(&payment).Error; err != nil {
+		return nil, errors.New("error creating payment: " + err.Error())
+	}
+
+	return &payment, nil
+}
+
+// CreateGuestPayment starts the payment of a guest order, authorized by its signed order link.
+func (ps *PaymentService) CreateGuestPayment(nomorOrder string, link *models.GuestOrderLinkRequest, gatewayName string) (*models.Payment, error) {
+	if err := verifyGuestOrderLink(nomorOrder, link); err != nil {
+		return nil, err
+	}
+
+	var order models.Order
+	if err := ps.DB.Where("nomor_order = ?", nomorOrder).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	return ps.CreatePayment(order.ID, order.UserID, gatewayName)
+}
+
+func (ps *PaymentService) GetPaymentsByOrder(orderID uint, userID uint) ([]models.Payment, error) {
+	var order models.Order
+	if err := ps.DB.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	var payments []models.Payment
+	if err := ps.DB.Where("order_id = ?", order.ID).Order("created_at DESC").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	if len(payments) == 0 {
+		return nil, errors.New("no payments found")
+	}
+
+	return payments, nil
+}
+
+func (ps *PaymentService) GetPaymentByReference(referensi string) (*models.Payment, error) {
+	var payment models.Payment
+
+	if err := ps.DB.Where("referensi = ?", referensi).First(&payment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("payment not found")
+		}
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (ps *PaymentService) HandleWebhook(gatewayName string, payload []byte, signature string) (*models.Payment, error) {
+	gateway, err := ps.GetGateway(gatewayName)
+	if err != nil {
+		return nil, err
+	}
+
+	notification, err := gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := ps.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var payment models.Payment
+	err = tx.Where("referensi = ? AND gateway = ?", notification.Referensi, gateway.Name()).First(&payment).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("payment not found")
+		}
+		return nil, err
+	}
+
+	// The order is locked before the payment, in the same order as order edits, so the total cannot
+	// change between the check below and the confirmation.
+	var order models.Order
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, payment.OrderID).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error loading order: " + err.Error())
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, payment.ID).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error loading payment: " + err.Error())
+	}
+
+	if payment.Status != "pending" {
+		tx.Rollback()
+		return &payment, nil
+	}
+
+	if notification.Nominal != payment.Nominal {
+		tx.Rollback()
+		return nil, errors.New("payment amount does not match")
+	}
+
+	payment.Status = notification.Status
+	if notification.Status == "settled" {
+		now := time.Now()
+		payment.DibayarPada = &now
+	}
+
+	if err := tx.Save(&payment).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating payment: " + err.Error())
+	}
+
+	// A payment for an older total of an order that was edited afterwards does not confirm it.
+	if payment.Status == "settled" && order.Status == "pending" && !order.DeletedAt.Valid {
+		if payment.Nominal != order.TotalHarga {
+			log.Printf("Payment %s of order %d does not match the order total, the order is not confirmed", payment.Referensi, order.ID)
+		} else {
+			order.Status = "confirmed"
+			if err := tx.Model(&order).Updates(map[string]any{"status": order.Status, "versi": gorm.Expr("versi + 1")}).Error; err != nil {
+				tx.Rollback()
+				return nil, errors.New("error confirming order: " + err.Error())
+			}
+			order.Versi++
+
+			if err := recordOrderEvent(tx, models.WebhookEventOrderStatusChanged, &order, "pending"); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return &payment, nil
+}
+
+func (ps *PaymentService) SimulateMockPayment(referensi string, userID uint, status string) (*models.Payment, error) {
+	gateway, err := ps.GetGateway("mock")
+	if err != nil {
+		return nil, err
+	}
+
+	mock, ok := gateway.(*MockPaymentGateway)
+	if !ok {
+		return nil, errors.New("mock payment gateway is not available")
+	}
+
+	payment, err := ps.GetPaymentByReference(referensi)
+	if err != nil {
+		return nil, err
+	}
+
+	var order models.Order
+	if err := ps.DB.Where("id = ? AND user_id = ?", payment.OrderID, userID).First(&order).Error; err != nil {
+		return nil, errors.New("payment not found")
+	}
+
+	payload, signature, err := mock.SignedNotification(&models.PaymentNotification{
+		Referensi: payment.Referensi,
+		Status:    status,
+		Nominal:   payment.Nominal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.HandleWebhook(mock.Name(), payload, signature)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"golang-api/models"
 	"io"
+	"log"
 	"os"
 	"path/filepath"
 
@@ -22,6 +23,15 @@ func NewProductService(db *gorm.DB) *ProductService {
 func (ps *ProductService) CreateProduct(product *models.Product, src io.Reader) (*models.Product, error) {
 	const uploadDir = "uploads"
 
+	if err := ps.setProductCurrency(product); err != nil {
+		return nil, err
+	}
+
+	if err := setProductStockMode(product); err != nil {
+		return nil, err
+	}
+
+	product.Versi = 1
 	if err := ps.DB.Create(product).Error; err != nil {
 		return nil, errors.New("error creating product " + err.Error())
 	}
@@ -94,16 +104,27 @@ func (ps *ProductService) GetProductByID(id uint) (*models.Product, error) {
 	return &product, nil
 }
 
-func (ps *ProductService) UpdateProduct(product *models.Product, src io.Reader) (*models.Product, error) {
+// UpdateProduct saves the product if it is still at versi. A new photo is written to a temporary
+// file first and only replaces fotoLama once the update is saved, so a version conflict leaves the
+// current photo in place.
+func (ps *ProductService) UpdateProduct(product *models.Product, src io.Reader, versi uint, fotoLama string) (*models.Product, error) {
 	const uploadDir = "uploads"
 
+	if err := ps.setProductCurrency(product); err != nil {
+		return nil, err
+	}
+
+	if err := setProductStockMode(product); err != nil {
+		return nil, err
+	}
+
 	if err := os.MkdirAll(uploadDir, 0775); err != nil {
 		return nil, fmt.Errorf("error creating directory: %v", err)
 	}
 
 	if product.FotoProduk == "" || src == nil {
-		if err := ps.DB.Save(product).Error; err != nil {
-			return nil, errors.New("error updating product " + err.Error())
+		if err := ps.saveProduct(product, versi); err != nil {
+			return nil, err
 		}
 		return product, nil
 	}
@@ -111,25 +132,112 @@ func (ps *ProductService) UpdateProduct(product *models.Product, src io.Reader)
 	cleanFilename := filepath.Base(product.FotoProduk)
 	pathFolder := filepath.Join(uploadDir, cleanFilename)
 
-	dst, err := os.Create(pathFolder)
+	dst, err := os.CreateTemp(uploadDir, cleanFilename+".*.tmp")
 	if err != nil {
 		return nil, fmt.Errorf("error creating file: %v", err)
 	}
-
-	defer dst.Close()
+	defer os.Remove(dst.Name())
 
 	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error saving file: %v", err)
 	}
 
-	if err := ps.DB.Save(product).Error; err != nil {
-		return nil, errors.New("error updating product " + err.Error())
+	if err := ps.saveProduct(product, versi); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(dst.Name(), pathFolder); err != nil {
+		return nil, fmt.Errorf("error saving file: %v", err)
+	}
+
+	if fotoLama != "" && fotoLama != cleanFilename {
+		if err := os.Remove(filepath.Join(uploadDir, filepath.Base(fotoLama))); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Old photo %s of product %d could not be deleted: %s", fotoLama, product.ID, err.Error())
+		}
 	}
 
 	return product, nil
 }
 
+func (ps *ProductService) saveProduct(product *models.Product, versi uint) error {
+	product.Versi = versi + 1
+	result := ps.DB.Model(product).Where("versi = ?", versi).Select("*").Updates(product)
+	if result.Error != nil {
+		return errors.New("error updating product " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// ConvertPrices returns the price of every product in the given currency at the current rates.
+func (ps *ProductService) ConvertPrices(products []models.Product, mataUang string) ([]models.Money, error) {
+	kursTujuan, err := getExchangeRate(ps.DB, mataUang)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := map[string]float64{}
+	prices := make([]models.Money, len(products))
+
+	for i, product := range products {
+		mataUangProduk := product.MataUang
+		if mataUangProduk == "" {
+			mataUangProduk = models.BaseCurrency
+		}
+
+		kurs, ok := rates[mataUangProduk]
+		if !ok {
+			kurs, err = getExchangeRate(ps.DB, mataUangProduk)
+			if err != nil {
+				return nil, err
+			}
+			rates[mataUangProduk] = kurs
+		}
+
+		prices[i] = convertMoney(product.Harga, kurs, kursTujuan)
+	}
+
+	return prices, nil
+}
+
+func (ps *ProductService) setProductCurrency(product *models.Product) error {
+	if product.MataUang == "" {
+		product.MataUang = models.BaseCurrency
+	}
+
+	code, err := normalizeCurrency(product.MataUang)
+	if err != nil {
+		return err
+	}
+
+	if _, err := getExchangeRate(ps.DB, code); err != nil {
+		return err
+	}
+
+	product.MataUang = code
+	return nil
+}
+
+func setProductStockMode(product *models.Product) error {
+	switch product.ModeStok {
+	case "", "normal":
+		product.ModeStok = "normal"
+		product.TersediaPada = nil
+	case "preorder":
+		if product.TersediaPada == nil {
+			return errors.New("pre-order products require an availability date")
+		}
+	}
+
+	return nil
+}
+
 func (ps *ProductService) DeleteProduct(id uint) error {
 	var product models.Product
 
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"errors"
+	"golang-api/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnService struct {
+	DB *gorm.DB
+}
+
+func NewReturnService(db *gorm.DB) *ReturnService {
+	return &ReturnService{DB: db}
+}
+
+func (rs *ReturnService) CreateReturn(orderID uint, userID uint, req *models.CreateReturnRequest) (*models.ReturnRequest, error) {
+	tx := rs.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	if err := tx.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	if order.Status != "delivered" {
+		tx.Rollback()
+		return nil, errors.New("only delivered orders can be returned")
+	}
+
+	var item models.OrderItem
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND order_id = ?", req.OrderItemID, order.ID).
+		First(&item).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order item not found")
+		}
+		return nil, err
+	}
+
+	var requested int64
+	err = tx.Model(&models.ReturnRequest{}).
+		Where("order_item_id = ? AND status <> ?", item.ID, "rejected").
+		Select("COALESCE(SUM(jumlah), 0)").
+		Scan(&requested).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if int64(req.Jumlah) > int64(item.Jumlah)-requested {
+		tx.Rollback()
+		return nil, errors.New("return quantity exceeds the returnable quantity of this item")
+	}
+
+	returnRequest := models.ReturnRequest{
+		OrderID:     order.ID,
+		OrderItemID: item.ID,
+		UserID:      userID,
+		Jumlah:      req.Jumlah,
+		Alasan:      req.Alasan,
+		Keterangan:  req.Keterangan,
+		Status:      "requested",
+	}
+
+	if err := tx.Create(&returnRequest).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error creating return request: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return rs.GetReturnByID(returnRequest.ID)
+}
+
+func (rs *ReturnService) GetReturnsByOrder(orderID uint, userID uint) ([]models.ReturnRequest, error) {
+	var returns []models.ReturnRequest
+
+	err := rs.DB.Preload("OrderItem").
+		Where("order_id = ? AND user_id = ?", orderID, userID).
+		Order("created_at DESC").
+		Find(&returns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(returns) == 0 {
+		return nil, errors.New("no return requests found")
+	}
+
+	return returns, nil
+}
+
+func (rs *ReturnService) GetReturns(req *models.GetReturnRequest) ([]models.ReturnRequest, error) {
+	var returns []models.ReturnRequest
+
+	query := rs.DB.Preload("OrderItem")
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	if req.OrderID != 0 {
+		query = query.Where("order_id = ?", req.OrderID)
+	}
+
+	err := query.Order("created_at DESC").Limit(req.Limit).Offset(req.Offset).Find(&returns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(returns) == 0 {
+		return nil, errors.New("no return requests found")
+	}
+
+	return returns, nil
+}
+
+func (rs *ReturnService) GetReturnByID(id uint) (*models.ReturnRequest, error) {
+	var returnRequest models.ReturnRequest
+
+	if err := rs.DB.Preload("OrderItem").First(&returnRequest, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("return request not found")
+		}
+		return nil, err
+	}
+
+	return &returnRequest, nil
+}
+
+func (rs *ReturnService) ApproveReturn(id uint, staffID uint, catatan string) (*models.ReturnRequest, error) {
+	return rs.reviewReturn(id, staffID, catatan, "approved")
+}
+
+func (rs *ReturnService) RejectReturn(id uint, staffID uint, catatan string) (*models.ReturnRequest, error) {
+	return rs.reviewReturn(id, staffID, catatan, "rejected")
+}
+
+func (rs *ReturnService) reviewReturn(id uint, staffID uint, catatan string, status string) (*models.ReturnRequest, error) {
+	tx := rs.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var returnRequest models.ReturnRequest
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("OrderItem").First(&returnRequest, id).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("return request not found")
+		}
+		return nil, err
+	}
+
+	if returnRequest.Status != "requested" {
+		tx.Rollback()
+		return nil, errors.New("return request has already been reviewed")
+	}
+
+	returnRequest.Status = status
+	returnRequest.CatatanStaff = catatan
+	returnRequest.DiprosesOleh = &staffID
+
+	if err := tx.Omit("OrderItem").Save(&returnRequest).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating return request: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return &returnRequest, nil
+}
+
+func (rs *ReturnService) ReceiveReturn(id uint, staffID uint, inventoryID uint) (*models.ReturnRequest, error) {
+	tx := rs.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var returnRequest models.ReturnRequest
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("OrderItem").First(&returnRequest, id).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("return request not found")
+		}
+		return nil, err
+	}
+
+	// A return can be refunded before its goods arrive, so receipt is tracked by diterima_pada.
+	if (returnRequest.Status != "approved" && returnRequest.Status != "refunded") || returnRequest.DiterimaPada != nil {
+		tx.Rollback()
+		return nil, errors.New("only approved or refunded return requests that were not received yet can be received")
+	}
+
+	var inventory models.Inventory
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&inventory, inventoryID).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("inventory not found")
+		}
+		return nil, err
+	}
+
+	if inventory.ProductID != returnRequest.OrderItem.ProductID {
+		tx.Rollback()
+		return nil, errors.New("inventory does not belong to the returned product")
+	}
+
+	inventory.Jumlah += returnRequest.Jumlah
+	inventory.Versi++
+	if err := tx.Save(&inventory).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating stock: " + err.Error())
+	}
+
+	if err := publishStockChanged(tx, &inventory, inventory.Jumlah-returnRequest.Jumlah); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if _, err := allocateBackorders(tx, inventory.ProductID); err != nil {
+		tx.Rollback()
+		return nil, errors.New("error allocating backorders: " + err.Error())
+	}
+
+	now := time.Now()
+	if returnRequest.Status == "approved" {
+		returnRequest.Status = "received"
+	}
+	returnRequest.InventoryID = &inventory.ID
+	returnRequest.DiterimaPada = &now
+	returnRequest.DiprosesOleh = &staffID
+
+	if err := tx.Omit("OrderItem").Save(&returnRequest).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating return request: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return &returnRequest, nil
+}
+
+func (rs *ReturnService) CreateRefund(orderID uint, staffID uint, req *models.CreateRefundRequest) (*models.Refund, error) {
+	tx := rs.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	// Only money that was received can be refunded; a cancelled order keeps its settled payment.
+	var settled int64
+	if err := tx.Model(&models.Payment{}).Where("order_id = ? AND status = ?", order.ID, "settled").Count(&settled).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if settled == 0 {
+		tx.Rollback()
+		return nil, errors.New("only paid orders can be refunded")
+	}
+
+	refundable := order.TotalHarga.Sub(order.TotalRefund)
+	nominal := req.Nominal
+
+	var returnRequest *models.ReturnRequest
+	if req.ReturnRequestID != 0 {
+		var found models.ReturnRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("OrderItem").
+			Where("id = ? AND order_id = ?", req.ReturnRequestID, order.ID).
+			First(&found).Error
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("return request not found")
+			}
+			return nil, err
+		}
+
+		if found.Status != "approved" && found.Status != "received" {
+			tx.Rollback()
+			return nil, errors.New("only approved or received return requests can be refunded")
+		}
+
+		if nominal == 0 && !req.Penuh {
+			line := found.OrderItem.Subtotal.Add(found.OrderItem.Pajak)
+			nominal = line.MulDiv(int64(found.Jumlah), int64(found.OrderItem.Jumlah))
+		}
+
+		returnRequest = &found
+	}
+
+	if req.Penuh {
+		nominal = refundable
+	}
+
+	if nominal <= 0 {
+		tx.Rollback()
+		return nil, errors.New("refund amount must be greater than zero")
+	}
+
+	if nominal > refundable {
+		tx.Rollback()
+		return nil, errors.New("refund amount exceeds the refundable amount of the order")
+	}
+
+	refund := models.Refund{
+		OrderID:      order.ID,
+		Nominal:      nominal,
+		Alasan:       req.Alasan,
+		DiprosesOleh: staffID,
+	}
+
+	if returnRequest != nil {
+		refund.ReturnRequestID = &returnRequest.ID
+	}
+
+	if err := tx.Create(&refund).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error creating refund: " + err.Error())
+	}
+
+	order.TotalRefund = order.TotalRefund.Add(refund.Nominal)
+	order.TotalBersih = order.TotalHarga.Sub(order.TotalRefund)
+
+	err := tx.Model(&order).Updates(map[string]interface{}{
+		"total_refund": order.TotalRefund,
+		"total_bersih": order.TotalBersih,
+		"versi":        gorm.Expr("versi + 1"),
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating order total: " + err.Error())
+	}
+
+	if returnRequest != nil {
+		err := tx.Model(&models.ReturnRequest{}).Where("id = ?", returnRequest.ID).Update("status", "refunded").Error
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.New("error updating return request: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return &refund, nil
+}
+
+func (rs *ReturnService) GetRefundsByOrder(orderID uint) ([]models.Refund, error) {
+	var refunds []models.Refund
+
+	if err := rs.DB.Where("order_id = ?", orderID).Order("created_at DESC").Find(&refunds).Error; err != nil {
+		return nil, err
+	}
+
+	if len(refunds) == 0 {
+		return nil, errors.New("no refunds found")
+	}
+
+	return refunds, nil
+}
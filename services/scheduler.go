@@ -0,0 +1,116 @@
+package services
+
+import (
+	"golang-api/config"
+	"golang-api/models"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// instanceID identifies this API process as the owner of job locks.
+var instanceID = uuid.New().String()
+
+// StartScheduler runs the background jobs of the service. Every run first takes the job lock
+// in the database, so with several API instances each job runs on one instance per interval.
+func StartScheduler(db *gorm.DB) {
+	orderService := NewOrderService(db)
+
+	if interval := config.GetOrderMaintenanceInterval(); interval > 0 {
+		go runScheduledJob(db, "order-maintenance", interval, orderService.RunOrderMaintenance)
+	}
+
+	if config.GetOrderPaymentTimeout() > 0 {
+		go runScheduledJob(db, "order-auto-cancel", config.GetOrderAutoCancelInterval(), orderService.RunAutoCancel)
+	}
+
+	if interval := config.GetSubscriptionRunInterval(); interval > 0 {
+		go runScheduledJob(db, "subscriptions", interval, NewSubscriptionService(db).RunSubscriptions)
+	}
+
+	if interval := config.GetWebhookDeliveryInterval(); interval > 0 {
+		go runScheduledJob(db, "webhooks", interval, NewWebhookService(db).DeliverWebhooks)
+	}
+
+	if interval := config.GetNotificationSendInterval(); interval > 0 {
+		go runScheduledJob(db, "notifications", interval, NewNotificationService(db).SendNotifications)
+	}
+}
+
+// jobLockLease is how long a job lock is held without renewal. A running job renews it every third
+// of the lease, so a job may take longer than its interval without another instance starting it.
+const jobLockLease = 2 * time.Minute
+
+func runScheduledJob(db *gorm.DB, nama string, interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := acquireJobLock(db, nama)
+		if err != nil {
+			log.Println("Error acquiring job lock " + nama + ": " + err.Error())
+		} else if acquired {
+			runLockedJob(db, nama, interval, job)
+		}
+
+		<-ticker.C
+	}
+}
+
+// runLockedJob runs a job while renewing its lock, then holds the lock until the next run is due so
+// the job runs once per interval across all instances.
+func runLockedJob(db *gorm.DB, nama string, interval time.Duration, job func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(jobLockLease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := renewJobLock(db, nama, jobLockLease); err != nil {
+					log.Println("Error renewing job lock " + nama + ": " + err.Error())
+				}
+			}
+		}
+	}()
+
+	job()
+	close(done)
+
+	if err := renewJobLock(db, nama, interval); err != nil {
+		log.Println("Error renewing job lock " + nama + ": " + err.Error())
+	}
+}
+
+// acquireJobLock takes the lock of a job when it expired or is already owned by this instance.
+// Expiry is compared with the database clock, so clock differences between instances do not matter.
+func acquireJobLock(db *gorm.DB, nama string) (bool, error) {
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.JobLock{Nama: nama, BerlakuSampai: time.Unix(0, 0)}).Error
+	if err != nil {
+		return false, err
+	}
+
+	result := db.Model(&models.JobLock{}).
+		Where("nama = ? AND (berlaku_sampai <= NOW(3) OR pemilik = ?)", nama, instanceID).
+		Updates(map[string]any{
+			"pemilik":        instanceID,
+			"berlaku_sampai": gorm.Expr("DATE_ADD(NOW(3), INTERVAL ? MICROSECOND)", jobLockLease.Microseconds()),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func renewJobLock(db *gorm.DB, nama string, lease time.Duration) error {
+	return db.Model(&models.JobLock{}).
+		Where("nama = ? AND pemilik = ?", nama, instanceID).
+		Update("berlaku_sampai", gorm.Expr("DATE_ADD(NOW(3), INTERVAL ? MICROSECOND)", lease.Microseconds())).Error
+}
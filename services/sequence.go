@@ -0,0 +1,26 @@
+package services
+
+import (
+	"golang-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func nextSequence(tx *gorm.DB, nama string) (uint64, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Sequence{Nama: nama}).Error; err != nil {
+		return 0, err
+	}
+
+	var sequence models.Sequence
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("nama = ?", nama).First(&sequence).Error; err != nil {
+		return 0, err
+	}
+
+	sequence.Nilai++
+	if err := tx.Model(&sequence).Where("nama = ?", nama).Update("nilai", sequence.Nilai).Error; err != nil {
+		return 0, err
+	}
+
+	return sequence.Nilai, nil
+}
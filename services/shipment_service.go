@@ -0,0 +1,273 @@
+package services
+
+import (
+	"errors"
+	"golang-api/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShipmentService struct {
+	DB *gorm.DB
+}
+
+func NewShipmentService(db *gorm.DB) *ShipmentService {
+	return &ShipmentService{DB: db}
+}
+
+func (ss *ShipmentService) CreateShipment(orderID uint, req *models.CreateShipmentRequest) (*models.Shipment, error) {
+	tx := ss.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	if order.Status != "confirmed" && order.Status != "partially_shipped" {
+		tx.Rollback()
+		return nil, errors.New("only confirmed or partially shipped orders can be shipped")
+	}
+
+	shipment := models.Shipment{
+		OrderID:     order.ID,
+		Kurir:       req.Kurir,
+		NomorResi:   req.NomorResi,
+		Lokasi:      req.Lokasi,
+		Status:      "shipped",
+		DikirimPada: time.Now(),
+	}
+
+	if err := tx.Create(&shipment).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.New("error creating shipment: " + err.Error())
+	}
+
+	for _, itemReq := range req.Items {
+		var item models.OrderItem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND order_id = ?", itemReq.OrderItemID, order.ID).
+			First(&item).Error
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("order item not found")
+			}
+			return nil, err
+		}
+
+		if itemReq.Jumlah > item.Jumlah-item.JumlahDikirim {
+			tx.Rollback()
+			return nil, errors.New("shipment quantity exceeds the remaining quantity of the order item")
+		}
+
+		if itemReq.Jumlah > item.Jumlah-item.JumlahDikirim-item.JumlahBackorder {
+			tx.Rollback()
+			return nil, errors.New("shipment quantity includes backordered units that have no stock yet")
+		}
+
+		var inventory models.Inventory
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND lokasi = ?", item.ProductID, req.Lokasi).
+			First(&inventory).Error
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("inventory not found for this product and location")
+			}
+			return nil, err
+		}
+
+		if inventory.Jumlah < itemReq.Jumlah {
+			tx.Rollback()
+			return nil, errors.New("insufficient stock at the shipping location")
+		}
+
+		inventory.Jumlah -= itemReq.Jumlah
+		inventory.Versi++
+		if err := tx.Save(&inventory).Error; err != nil {
+			tx.Rollback()
+			return nil, errors.New("error updating stock: " + err.Error())
+		}
+
+		if err := publishStockChanged(tx, &inventory, inventory.Jumlah+itemReq.Jumlah); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		item.JumlahDikirim += itemReq.Jumlah
+		if err := tx.Model(&item).Update("jumlah_dikirim", item.JumlahDikirim).Error; err != nil {
+			tx.Rollback()
+			return nil, errors.New("error updating order item: " + err.Error())
+		}
+
+		shipmentItem := models.ShipmentItem{
+			ShipmentID:  shipment.ID,
+			OrderItemID: item.ID,
+			Jumlah:      itemReq.Jumlah,
+		}
+
+		if err := tx.Create(&shipmentItem).Error; err != nil {
+			tx.Rollback()
+			return nil, errors.New("error creating shipment item: " + err.Error())
+		}
+	}
+
+	if err := ss.syncOrderStatus(tx, &order); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return ss.GetShipmentByID(shipment.ID)
+}
+
+func (ss *ShipmentService) GetShipmentByID(id uint) (*models.Shipment, error) {
+	var shipment models.Shipment
+
+	if err := ss.DB.Preload("Items.OrderItem").First(&shipment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("shipment not found")
+		}
+		return nil, err
+	}
+
+	return &shipment, nil
+}
+
+func (ss *ShipmentService) GetShipmentsByOrder(orderID uint) ([]models.Shipment, error) {
+	var shipments []models.Shipment
+
+	err := ss.DB.Preload("Items.OrderItem").Where("order_id = ?", orderID).Order("dikirim_pada ASC").Find(&shipments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shipments) == 0 {
+		return nil, errors.New("no shipments found")
+	}
+
+	return shipments, nil
+}
+
+func (ss *ShipmentService) GetShipmentsByOrderAndUserID(orderID uint, userID uint) ([]models.Shipment, error) {
+	var order models.Order
+	if err := ss.DB.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	return ss.GetShipmentsByOrder(order.ID)
+}
+
+func (ss *ShipmentService) MarkDelivered(id uint) (*models.Shipment, error) {
+	tx := ss.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var shipment models.Shipment
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&shipment, id).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("shipment not found")
+		}
+		return nil, err
+	}
+
+	if shipment.Status == "delivered" {
+		tx.Rollback()
+		return nil, errors.New("shipment has already been delivered")
+	}
+
+	now := time.Now()
+	err := tx.Model(&shipment).Updates(map[string]interface{}{
+		"status":        "delivered",
+		"diterima_pada": &now,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating shipment: " + err.Error())
+	}
+
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, shipment.OrderID).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := ss.syncOrderStatus(tx, &order); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return ss.GetShipmentByID(shipment.ID)
+}
+
+func (ss *ShipmentService) syncOrderStatus(tx *gorm.DB, order *models.Order) error {
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		return err
+	}
+
+	fullyShipped := true
+	anyShipped := false
+	for _, item := range items {
+		if item.JumlahDikirim < item.Jumlah {
+			fullyShipped = false
+		}
+		if item.JumlahDikirim > 0 {
+			anyShipped = true
+		}
+	}
+
+	var undelivered int64
+	err := tx.Model(&models.Shipment{}).Where("order_id = ? AND status <> ?", order.ID, "delivered").Count(&undelivered).Error
+	if err != nil {
+		return err
+	}
+
+	status := order.Status
+	switch {
+	case fullyShipped && undelivered == 0:
+		status = "delivered"
+	case fullyShipped:
+		status = "shipped"
+	case anyShipped:
+		status = "partially_shipped"
+	}
+
+	if status == order.Status {
+		return nil
+	}
+
+	if err := tx.Model(order).Updates(map[string]any{"status": status, "versi": gorm.Expr("versi + 1")}).Error; err != nil {
+		return errors.New("error updating order status: " + err.Error())
+	}
+
+	statusSebelum := order.Status
+	order.Status = status
+
+	return recordOrderEvent(tx, models.WebhookEventOrderStatusChanged, order, statusSebelum)
+}
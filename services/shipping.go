@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"golang-api/config"
+	"golang-api/models"
+	"math"
+)
+
+type ShippingQuote struct {
+	Subtotal   models.Money
+	BeratTotal int
+	Alamat     models.ShippingAddress
+}
+
+type ShippingCalculator interface {
+	Name() string
+	Calculate(quote ShippingQuote) (models.Money, error)
+}
+
+type FlatRateShipping struct {
+	Rate models.Money
+}
+
+func (f *FlatRateShipping) Name() string {
+	return "flat"
+}
+
+func (f *FlatRateShipping) Calculate(quote ShippingQuote) (models.Money, error) {
+	return f.Rate, nil
+}
+
+type WeightBasedShipping struct {
+	Rates      []config.WeightRate
+	ExtraPerKg models.Money
+}
+
+func (w *WeightBasedShipping) Name() string {
+	return "weight"
+}
+
+func (w *WeightBasedShipping) Calculate(quote ShippingQuote) (models.Money, error) {
+	if len(w.Rates) == 0 {
+		return 0, errors.New("weight based shipping has no rates configured")
+	}
+
+	for _, rate := range w.Rates {
+		if quote.BeratTotal <= rate.MaxBerat {
+			return rate.Ongkir, nil
+		}
+	}
+
+	heaviest := w.Rates[len(w.Rates)-1]
+	extraKg := int(math.Ceil(float64(quote.BeratTotal-heaviest.MaxBerat) / 1000))
+
+	return heaviest.Ongkir.Add(w.ExtraPerKg.Mul(extraKg)), nil
+}
+
+type FreeShippingAboveThreshold struct {
+	Threshold models.Money
+	Fallback  ShippingCalculator
+}
+
+func (f *FreeShippingAboveThreshold) Name() string {
+	return f.Fallback.Name()
+}
+
+func (f *FreeShippingAboveThreshold) Calculate(quote ShippingQuote) (models.Money, error) {
+	if f.Threshold > 0 && quote.Subtotal >= f.Threshold {
+		return 0, nil
+	}
+
+	return f.Fallback.Calculate(quote)
+}
+
+func NewShippingCalculators() map[string]ShippingCalculator {
+	calculators := []ShippingCalculator{
+		&FlatRateShipping{Rate: config.GetShippingFlatRate()},
+		&WeightBasedShipping{
+			Rates:      config.GetShippingWeightRates(),
+			ExtraPerKg: config.GetShippingExtraPerKg(),
+		},
+	}
+
+	threshold := config.GetShippingFreeThreshold()
+	registry := make(map[string]ShippingCalculator)
+
+	for _, calculator := range calculators {
+		if threshold > 0 {
+			calculator = &FreeShippingAboveThreshold{Threshold: threshold, Fallback: calculator}
+		}
+		registry[calculator.Name()] = calculator
+	}
+
+	return registry
+}
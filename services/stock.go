@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"golang-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var activeOrderStatuses = []string{"pending", "confirmed", "partially_shipped"}
+
+func availableStock(tx *gorm.DB, productID uint) (int, error) {
+	var inventories []models.Inventory
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", productID).Find(&inventories).Error; err != nil {
+		return 0, err
+	}
+
+	onHand := 0
+	for _, inventory := range inventories {
+		onHand += inventory.Jumlah
+	}
+
+	var committed int64
+	err := tx.Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("order_items.product_id = ? AND orders.status IN ?", productID, activeOrderStatuses).
+		Select("COALESCE(SUM(order_items.jumlah - order_items.jumlah_dikirim), 0)").
+		Scan(&committed).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return onHand - int(committed), nil
+}
+
+// reserveStock checks that jumlah more units of the product can be ordered and returns how many
+// of them are not covered by stock. Products in backorder or preorder mode accept the missing units;
+// stock that is already promised to earlier orders is never taken from them.
+func reserveStock(tx *gorm.DB, product *models.Product, jumlah int) (int, error) {
+	available, err := availableStock(tx, product.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	if available >= jumlah {
+		return 0, nil
+	}
+
+	if product.ModeStok != "backorder" && product.ModeStok != "preorder" {
+		return 0, fmt.Errorf("insufficient stock for product %s: %d available", product.Nama, max(available, 0))
+	}
+
+	return jumlah - max(available, 0), nil
+}
+
+// setBackorder marks how much of an order item is waiting for stock.
+func setBackorder(item *models.OrderItem, product *models.Product, jumlahBackorder int) {
+	item.JumlahBackorder = jumlahBackorder
+	if jumlahBackorder > 0 {
+		item.JenisBackorder = product.ModeStok
+		item.TersediaPada = product.TersediaPada
+	} else {
+		item.JenisBackorder = ""
+		item.TersediaPada = nil
+	}
+}
+
+// allocateOrderBackorders allocates the stock an order no longer needs, after it was cancelled,
+// deleted or archived, to the backordered items of its products.
+func allocateOrderBackorders(tx *gorm.DB, productIDs []uint) error {
+	for _, productID := range productIDs {
+		if _, err := allocateBackorders(tx, productID); err != nil {
+			return errors.New("error allocating backorders: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func orderProductIDs(tx *gorm.DB, orderID uint) ([]uint, error) {
+	var productIDs []uint
+	err := tx.Unscoped().Model(&models.OrderItem{}).Where("order_id = ?", orderID).Distinct().Pluck("product_id", &productIDs).Error
+
+	return productIDs, err
+}
+
+// allocateBackorders hands stock of the product that is not promised to any order yet to
+// backordered items, oldest order first. It returns the number of units allocated.
+func allocateBackorders(tx *gorm.DB, productID uint) (int, error) {
+	var inventories []models.Inventory
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", productID).Find(&inventories).Error; err != nil {
+		return 0, err
+	}
+
+	onHand := 0
+	for _, inventory := range inventories {
+		onHand += inventory.Jumlah
+	}
+
+	var allocated int64
+	err := tx.Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("order_items.product_id = ? AND orders.status IN ?", productID, activeOrderStatuses).
+		Select("COALESCE(SUM(order_items.jumlah - order_items.jumlah_dikirim - order_items.jumlah_backorder), 0)").
+		Scan(&allocated).Error
+	if err != nil {
+		return 0, err
+	}
+
+	free := onHand - int(allocated)
+	if free <= 0 {
+		return 0, nil
+	}
+
+	var items []models.OrderItem
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("order_items.product_id = ? AND order_items.jumlah_backorder > 0 AND orders.status IN ?", productID, activeOrderStatuses).
+		Order("orders.tanggal_order ASC, order_items.id ASC").
+		Find(&items).Error
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, item := range items {
+		if free == 0 {
+			break
+		}
+
+		jumlah := min(free, item.JumlahBackorder)
+		item.JumlahBackorder -= jumlah
+		if item.JumlahBackorder == 0 {
+			item.JenisBackorder = ""
+			item.TersediaPada = nil
+		}
+
+		err := tx.Model(&item).Select("jumlah_backorder", "jenis_backorder", "tersedia_pada").Updates(&item).Error
+		if err != nil {
+			return total, err
+		}
+
+		free -= jumlah
+		total += jumlah
+	}
+
+	return total, nil
+}
@@ -0,0 +1,389 @@
+package services
+
+import (
+	"errors"
+	"golang-api/config"
+	"golang-api/models"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubscriptionService struct {
+	DB           *gorm.DB
+	OrderService *OrderService
+}
+
+func NewSubscriptionService(db *gorm.DB) *SubscriptionService {
+	return &SubscriptionService{
+		DB:           db,
+		OrderService: NewOrderService(db),
+	}
+}
+
+func (ss *SubscriptionService) CreateSubscription(userID uint, req *models.CreateSubscriptionRequest) (*models.Subscription, error) {
+	address, err := ss.OrderService.resolveShippingAddress(ss.DB, userID, req.AddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	metodePengiriman := req.MetodePengiriman
+	if metodePengiriman == "" {
+		metodePengiriman = config.GetShippingDefaultMethod()
+	}
+
+	if _, err := ss.OrderService.getShippingCalculator(metodePengiriman); err != nil {
+		return nil, err
+	}
+
+	mataUang := req.MataUang
+	if mataUang == "" {
+		mataUang = models.BaseCurrency
+	}
+
+	mataUang, err = normalizeCurrency(mataUang)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := getExchangeRate(ss.DB, mataUang); err != nil {
+		return nil, err
+	}
+
+	mulaiPada := time.Now()
+	if req.MulaiPada != "" {
+		mulaiPada, err = time.ParseInLocation("2006-01-02", req.MulaiPada, time.Local)
+		if err != nil {
+			return nil, errors.New("invalid start date")
+		}
+	}
+
+	intervalHari := 0
+	if req.Jadwal == "custom" {
+		intervalHari = req.IntervalHari
+	}
+
+	subscription := models.Subscription{
+		UserID:           userID,
+		AddressID:        address.ID,
+		MetodePengiriman: metodePengiriman,
+		MataUang:         mataUang,
+		Jadwal:           req.Jadwal,
+		IntervalHari:     intervalHari,
+		TanggalJadwal:    mulaiPada.Day(),
+		Status:           "active",
+		BerikutnyaPada:   mulaiPada,
+	}
+
+	for _, item := range req.Items {
+		var product models.Product
+		if err := ss.DB.First(&product, item.ProductID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("product not found")
+			}
+			return nil, err
+		}
+
+		subscription.Items = append(subscription.Items, models.SubscriptionItem{
+			ProductID: item.ProductID,
+			Jumlah:    item.Jumlah,
+		})
+	}
+
+	if err := ss.DB.Create(&subscription).Error; err != nil {
+		return nil, errors.New("error creating subscription: " + err.Error())
+	}
+
+	return ss.GetSubscriptionByID(subscription.ID, userID)
+}
+
+func (ss *SubscriptionService) GetSubscriptions(userID uint) ([]models.Subscription, error) {
+	var subscriptions []models.Subscription
+
+	err := ss.DB.Preload("Items.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("user_id = ?", userID).Order("created_at DESC").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, errors.New("no subscriptions found")
+	}
+
+	return subscriptions, nil
+}
+
+func (ss *SubscriptionService) GetSubscriptionByID(id uint, userID uint) (*models.Subscription, error) {
+	var subscription models.Subscription
+
+	err := ss.DB.Preload("Items.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("id = ? AND user_id = ?", id, userID).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("subscription not found")
+		}
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (ss *SubscriptionService) GetSubscriptionRuns(id uint, userID uint) ([]models.SubscriptionRun, error) {
+	if _, err := ss.GetSubscriptionByID(id, userID); err != nil {
+		return nil, err
+	}
+
+	var runs []models.SubscriptionRun
+	if err := ss.DB.Where("subscription_id = ?", id).Order("created_at DESC").Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	if len(runs) == 0 {
+		return nil, errors.New("no subscription runs found")
+	}
+
+	return runs, nil
+}
+
+func (ss *SubscriptionService) PauseSubscription(id uint, userID uint) (*models.Subscription, error) {
+	return ss.changeStatus(id, userID, func(subscription *models.Subscription) error {
+		if subscription.Status != "active" {
+			return errors.New("only active subscriptions can be paused")
+		}
+
+		subscription.Status = "paused"
+		return nil
+	})
+}
+
+// ResumeSubscription reactivates a paused subscription. Due dates missed while it was paused are skipped.
+func (ss *SubscriptionService) ResumeSubscription(id uint, userID uint) (*models.Subscription, error) {
+	return ss.changeStatus(id, userID, func(subscription *models.Subscription) error {
+		if subscription.Status != "paused" {
+			return errors.New("only paused subscriptions can be resumed")
+		}
+
+		now := time.Now()
+		for subscription.BerikutnyaPada.Before(now) {
+			subscription.BerikutnyaPada = nextSubscriptionDate(subscription)
+		}
+
+		subscription.Status = "active"
+		subscription.GagalBeruntun = 0
+		subscription.PesanGagal = ""
+		return nil
+	})
+}
+
+func (ss *SubscriptionService) CancelSubscription(id uint, userID uint) (*models.Subscription, error) {
+	return ss.changeStatus(id, userID, func(subscription *models.Subscription) error {
+		if subscription.Status == "cancelled" {
+			return errors.New("subscription is already cancelled")
+		}
+
+		subscription.Status = "cancelled"
+		return nil
+	})
+}
+
+func (ss *SubscriptionService) changeStatus(id uint, userID uint, change func(subscription *models.Subscription) error) (*models.Subscription, error) {
+	tx := ss.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var subscription models.Subscription
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&subscription).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("subscription not found")
+		}
+		return nil, err
+	}
+
+	if err := change(&subscription); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Model(&subscription).Select("status", "berikutnya_pada", "gagal_beruntun", "pesan_gagal").Updates(&subscription).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.New("error updating subscription: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return ss.GetSubscriptionByID(subscription.ID, userID)
+}
+
+// RunDueSubscriptions creates the orders of every active subscription that is due. A failed run
+// (out of stock, deleted product or address) is recorded and the subscription moves on to its next
+// date; after SUBSCRIPTION_MAX_FAILURES failures in a row the subscription is paused.
+func (ss *SubscriptionService) RunDueSubscriptions(now time.Time) (int, error) {
+	var ids []uint
+	err := ss.DB.Model(&models.Subscription{}).
+		Where("status = ? AND berikutnya_pada <= ?", "active", now).
+		Order("berikutnya_pada ASC").Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, id := range ids {
+		ok, err := ss.runSubscription(id, now)
+		if err != nil {
+			return created, err
+		}
+
+		if ok {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// runSubscription creates the order of a due subscription and records the run in one transaction, so
+// an order is never left without its run or subscription link. A failed order is rolled back to a
+// savepoint and recorded as a failed run in the same transaction.
+func (ss *SubscriptionService) runSubscription(id uint, now time.Time) (bool, error) {
+	tx := ss.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var subscription models.Subscription
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").
+		Where("id = ? AND status = ? AND berikutnya_pada <= ?", id, "active", now).
+		First(&subscription).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	jadwalPada := subscription.BerikutnyaPada
+	for !subscription.BerikutnyaPada.After(now) {
+		subscription.BerikutnyaPada = nextSubscriptionDate(&subscription)
+	}
+
+	req := models.CreateOrderRequest{
+		AddressID:        subscription.AddressID,
+		MetodePengiriman: subscription.MetodePengiriman,
+		MataUang:         subscription.MataUang,
+	}
+	for _, item := range subscription.Items {
+		req.Items = append(req.Items, models.CreateOrderItemRequest{
+			ProductID: item.ProductID,
+			Jumlah:    item.Jumlah,
+		})
+	}
+
+	run := models.SubscriptionRun{
+		SubscriptionID: subscription.ID,
+		JadwalPada:     jadwalPada,
+	}
+
+	if err := tx.SavePoint("subscription_order").Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	order, orderErr := ss.OrderService.createOrder(tx, subscription.UserID, &req)
+	if orderErr == nil {
+		run.Status = "created"
+		run.OrderID = &order.ID
+
+		if err := tx.Model(order).Update("subscription_id", subscription.ID).Error; err != nil {
+			tx.Rollback()
+			return false, errors.New("error linking order to subscription: " + err.Error())
+		}
+
+		subscription.GagalBeruntun = 0
+		subscription.PesanGagal = ""
+	} else {
+		// A deadlock or lock wait timeout already rolled back the whole transaction; the run is
+		// retried next time because the due date was not moved.
+		if err := tx.RollbackTo("subscription_order").Error; err != nil {
+			tx.Rollback()
+			return false, errors.New("error creating subscription order: " + orderErr.Error())
+		}
+
+		run.Status = "failed"
+		run.Keterangan = orderErr.Error()
+
+		subscription.GagalBeruntun++
+		subscription.PesanGagal = orderErr.Error()
+		if subscription.GagalBeruntun >= config.GetSubscriptionMaxFailures() {
+			subscription.Status = "paused"
+		}
+
+		log.Printf("Subscription %d failed to create an order: %s", subscription.ID, orderErr.Error())
+	}
+
+	subscription.TerakhirPada = &now
+
+	if err := tx.Create(&run).Error; err != nil {
+		tx.Rollback()
+		return false, errors.New("error recording subscription run: " + err.Error())
+	}
+
+	err = tx.Model(&subscription).Select("status", "berikutnya_pada", "terakhir_pada", "gagal_beruntun", "pesan_gagal").Updates(&subscription).Error
+	if err != nil {
+		tx.Rollback()
+		return false, errors.New("error updating subscription: " + err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, errors.New("error committing transaction: " + err.Error())
+	}
+
+	return orderErr == nil, nil
+}
+
+// RunSubscriptions is the scheduled job that creates the orders of due subscriptions.
+func (ss *SubscriptionService) RunSubscriptions() {
+	created, err := ss.RunDueSubscriptions(time.Now())
+	if err != nil {
+		log.Println("Error running subscriptions: " + err.Error())
+	} else if created > 0 {
+		log.Printf("Created %d subscription orders", created)
+	}
+}
+
+// nextSubscriptionDate returns the run after BerikutnyaPada. Monthly runs fall on the day of the
+// month the subscription started, or on the last day of shorter months, so a subscription started
+// on the 31st runs on Feb 28 and then again on Mar 31.
+func nextSubscriptionDate(subscription *models.Subscription) time.Time {
+	switch subscription.Jadwal {
+	case "weekly":
+		return subscription.BerikutnyaPada.AddDate(0, 0, 7)
+	case "monthly":
+		tanggal := subscription.TanggalJadwal
+		if tanggal == 0 {
+			tanggal = subscription.BerikutnyaPada.Day()
+		}
+
+		current := subscription.BerikutnyaPada
+		year, month, _ := current.Date()
+		lastDay := time.Date(year, month+2, 0, 0, 0, 0, 0, current.Location()).Day()
+
+		return time.Date(year, month+1, min(tanggal, lastDay), current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
+	default:
+		return subscription.BerikutnyaPada.AddDate(0, 0, max(subscription.IntervalHari, 1))
+	}
+}
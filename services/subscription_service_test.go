@@ -0,0 +1,38 @@
+package services
+
+import (
+	"golang-api/models"
+	"testing"
+	"time"
+)
+
+func TestNextSubscriptionDate(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name         string
+		subscription models.Subscription
+		want         time.Time
+	}{
+		{name: "weekly", subscription: models.Subscription{Jadwal: "weekly", BerikutnyaPada: date(2024, time.January, 29)}, want: date(2024, time.February, 5)},
+		{name: "custom", subscription: models.Subscription{Jadwal: "custom", IntervalHari: 10, BerikutnyaPada: date(2024, time.January, 25)}, want: date(2024, time.February, 4)},
+		{name: "custom without interval", subscription: models.Subscription{Jadwal: "custom", BerikutnyaPada: date(2024, time.January, 25)}, want: date(2024, time.January, 26)},
+		{name: "monthly", subscription: models.Subscription{Jadwal: "monthly", TanggalJadwal: 15, BerikutnyaPada: date(2024, time.January, 15)}, want: date(2024, time.February, 15)},
+		{name: "monthly clamped to leap february", subscription: models.Subscription{Jadwal: "monthly", TanggalJadwal: 31, BerikutnyaPada: date(2024, time.January, 31)}, want: date(2024, time.February, 29)},
+		{name: "monthly clamped to february", subscription: models.Subscription{Jadwal: "monthly", TanggalJadwal: 31, BerikutnyaPada: date(2023, time.January, 31)}, want: date(2023, time.February, 28)},
+		{name: "monthly back to anchor day", subscription: models.Subscription{Jadwal: "monthly", TanggalJadwal: 31, BerikutnyaPada: date(2024, time.February, 29)}, want: date(2024, time.March, 31)},
+		{name: "monthly clamped to april", subscription: models.Subscription{Jadwal: "monthly", TanggalJadwal: 31, BerikutnyaPada: date(2024, time.March, 31)}, want: date(2024, time.April, 30)},
+		{name: "monthly over new year", subscription: models.Subscription{Jadwal: "monthly", TanggalJadwal: 31, BerikutnyaPada: date(2024, time.December, 31)}, want: date(2025, time.January, 31)},
+		{name: "monthly without anchor", subscription: models.Subscription{Jadwal: "monthly", BerikutnyaPada: date(2024, time.March, 30)}, want: date(2024, time.April, 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextSubscriptionDate(&tt.subscription); !got.Equal(tt.want) {
+				t.Errorf("nextSubscriptionDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
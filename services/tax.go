@@ -0,0 +1,18 @@
+package services
+
+import (
+	"golang-api/models"
+	"math"
+)
+
+func calculateTax(harga models.Money, jumlah int, rate float64, inclusive bool) (models.Money, models.Money) {
+	gross := harga.Mul(jumlah)
+	basisPoints := int64(math.Round(rate * 100))
+
+	if inclusive {
+		pajak := gross.MulDiv(basisPoints, 10000+basisPoints)
+		return gross.Sub(pajak), pajak
+	}
+
+	return gross, gross.Percent(rate)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"golang-api/models"
+	"testing"
+)
+
+func TestCalculateTax(t *testing.T) {
+	tests := []struct {
+		name      string
+		harga     models.Money
+		jumlah    int
+		rate      float64
+		inclusive bool
+		wantDasar models.Money
+		wantPajak models.Money
+	}{
+		{name: "exclusive", harga: 10000, jumlah: 2, rate: 11, wantDasar: 20000, wantPajak: 2200},
+		{name: "exclusive rounds half up", harga: 50, jumlah: 1, rate: 11, wantDasar: 50, wantPajak: 6},
+		{name: "inclusive", harga: 11100, jumlah: 1, rate: 11, inclusive: true, wantDasar: 10000, wantPajak: 1100},
+		{name: "inclusive rounds", harga: 1000, jumlah: 3, rate: 11, inclusive: true, wantDasar: 2703, wantPajak: 297},
+		{name: "no tax", harga: 10000, jumlah: 1, rate: 0, wantDasar: 10000, wantPajak: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dasar, pajak := calculateTax(tt.harga, tt.jumlah, tt.rate, tt.inclusive)
+			if dasar != tt.wantDasar || pajak != tt.wantPajak {
+				t.Errorf("calculateTax() = (%d, %d), want (%d, %d)", dasar, pajak, tt.wantDasar, tt.wantPajak)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned when an update is based on a version of the row that has
+// been changed by someone else in the meantime.
+var ErrVersionConflict = errors.New("version conflict: the data was changed by another request, reload it and try again")
+
+// bumpVersion increments the versi column of a row changed outside the version-checked updates,
+// so clients still holding the old version get a conflict instead of overwriting the change.
+func bumpVersion(tx *gorm.DB, model any) error {
+	return tx.Model(model).UpdateColumn("versi", gorm.Expr("versi + 1")).Error
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"golang-api/config"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// newWebhookClient returns the HTTP client for webhook deliveries. It does not follow redirects and
+// refuses to connect to loopback, private and link-local addresses, so a webhook URL cannot be used
+// to reach services inside the network of the API. The address is checked when connecting, after
+// DNS resolution, so a host name that resolves to an internal address is refused as well.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !webhookTargetAllowed(ip) {
+				return errors.New("webhook target address is not allowed: " + host)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   config.GetWebhookTimeout(),
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func webhookTargetAllowed(ip net.IP) bool {
+	if config.AllowWebhookPrivateTargets() {
+		return true
+	}
+
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+func validateWebhookURL(rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errors.New("webhook url must be an http or https url")
+	}
+
+	if ip := net.ParseIP(target.Hostname()); ip != nil && !webhookTargetAllowed(ip) {
+		return errors.New("webhook url must not point to a loopback, private or link-local address")
+	}
+
+	return nil
+}
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang-api/config"
+	"golang-api/models"
+	"golang-api/utils"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	webhookDeliveryBatch = 100
+	maxWebhookRetryDelay = 24 * time.Hour
+)
+
+type WebhookService struct {
+	DB     *gorm.DB
+	Client *http.Client
+}
+
+func NewWebhookService(db *gorm.DB) *WebhookService {
+	return &WebhookService{
+		DB:     db,
+		Client: newWebhookClient(),
+	}
+}
+
+func (ws *WebhookService) CreateWebhook(req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		buf := make([]byte, 24)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, errors.New("error generating webhook secret: " + err.Error())
+		}
+		secret = "whsec_" + hex.EncodeToString(buf)
+	}
+
+	webhook := models.Webhook{
+		URL:    req.URL,
+		Events: joinWebhookEvents(req.Events),
+		Secret: secret,
+		Aktif:  true,
+	}
+
+	if err := ws.DB.Create(&webhook).Error; err != nil {
+		return nil, errors.New("error creating webhook: " + err.Error())
+	}
+
+	return &webhook, nil
+}
+
+func (ws *WebhookService) GetWebhooks() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+
+	if err := ws.DB.Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, errors.New("no webhooks found")
+	}
+
+	return webhooks, nil
+}
+
+func (ws *WebhookService) GetWebhookByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+
+	if err := ws.DB.First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// UpdateWebhook changes the endpoint. Enabling a webhook again clears its failure count, so
+// deliveries that were held back while it was disabled are sent on the next run.
+func (ws *WebhookService) UpdateWebhook(id uint, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	webhook, err := ws.GetWebhookByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != "" {
+		if err := validateWebhookURL(req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = req.URL
+	}
+	if len(req.Events) > 0 {
+		webhook.Events = joinWebhookEvents(req.Events)
+	}
+	if req.Aktif != nil {
+		if *req.Aktif && !webhook.Aktif {
+			webhook.GagalBeruntun = 0
+			webhook.DinonaktifkanPada = nil
+		}
+		webhook.Aktif = *req.Aktif
+	}
+
+	err = ws.DB.Model(webhook).Select("url", "events", "aktif", "gagal_beruntun", "dinonaktifkan_pada").Updates(webhook).Error
+	if err != nil {
+		return nil, errors.New("error updating webhook: " + err.Error())
+	}
+
+	return webhook, nil
+}
+
+func (ws *WebhookService) DeleteWebhook(id uint) error {
+	webhook, err := ws.GetWebhookByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := ws.DB.Delete(webhook).Error; err != nil {
+		return errors.New("error deleting webhook: " + err.Error())
+	}
+
+	return nil
+}
+
+func (ws *WebhookService) GetWebhookDeliveries(webhookID uint, req *models.GetWebhookDeliveryRequest) ([]models.WebhookDelivery, int64, error) {
+	if _, err := ws.GetWebhookByID(webhookID); err != nil {
+		return nil, 0, err
+	}
+
+	query := ws.DB.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.Event != "" {
+		query = query.Where("event = ?", req.Event)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Order("id DESC").Limit(req.Limit).Offset(req.Offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, 0, errors.New("no webhook deliveries found")
+	}
+
+	return deliveries, total, nil
+}
+
+// ReplayWebhookDelivery queues the payload of an earlier delivery again as a new delivery, keeping
+// the original entry of the log untouched. The event ID stays the same.
+func (ws *WebhookService) ReplayWebhookDelivery(webhookID uint, deliveryID uint) (*models.WebhookDelivery, error) {
+	var original models.WebhookDelivery
+	if err := ws.DB.Where("id = ? AND webhook_id = ?", deliveryID, webhookID).First(&original).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		return nil, err
+	}
+
+	delivery := models.WebhookDelivery{
+		WebhookID:      original.WebhookID,
+		EventID:        original.EventID,
+		Event:          original.Event,
+		Payload:        original.Payload,
+		Status:         "pending",
+		BerikutnyaPada: time.Now(),
+		ReplayDari:     &original.ID,
+	}
+
+	if err := ws.DB.Create(&delivery).Error; err != nil {
+		return nil, errors.New("error creating webhook delivery: " + err.Error())
+	}
+
+	return &delivery, nil
+}
+
+// DeliverDueWebhooks sends the pending deliveries that are due. A failed attempt is retried with
+// exponential backoff until WEBHOOK_MAX_ATTEMPTS, and a webhook is disabled after
+// WEBHOOK_MAX_FAILURES failed attempts in a row.
+func (ws *WebhookService) DeliverDueWebhooks(now time.Time) (int, error) {
+	activeWebhooks := ws.DB.Model(&models.Webhook{}).Select("id").Where("aktif = ?", true)
+
+	var deliveries []models.WebhookDelivery
+	err := ws.DB.Where("status = ? AND berikutnya_pada <= ? AND webhook_id IN (?)", "pending", now, activeWebhooks).
+		Order("id ASC").Limit(webhookDeliveryBatch).Find(&deliveries).Error
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[uint]*models.Webhook)
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = ws.GetWebhookByID(delivery.WebhookID)
+			if err != nil {
+				return delivered, err
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if !webhook.Aktif {
+			continue
+		}
+
+		claimed, err := ws.claimDelivery(delivery, now)
+		if err != nil {
+			return delivered, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		sent, err := ws.deliver(webhook, delivery, now)
+		if err != nil {
+			return delivered, err
+		}
+
+		if sent {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// claimDelivery moves the next attempt of a delivery past the time one attempt can take, unless
+// another instance changed the delivery since it was loaded. Only the instance that claimed it
+// sends it; if that instance stops, the delivery is tried again once the claim runs out.
+func (ws *WebhookService) claimDelivery(delivery *models.WebhookDelivery, now time.Time) (bool, error) {
+	berikutnyaPada := now.Add(config.GetWebhookTimeout() + time.Minute)
+
+	result := ws.DB.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND percobaan = ? AND berikutnya_pada = ?", delivery.ID, "pending", delivery.Percobaan, delivery.BerikutnyaPada).
+		Update("berikutnya_pada", berikutnyaPada)
+	if result.Error != nil {
+		return false, errors.New("error claiming webhook delivery: " + result.Error.Error())
+	}
+
+	delivery.BerikutnyaPada = berikutnyaPada
+
+	return result.RowsAffected == 1, nil
+}
+
+func (ws *WebhookService) deliver(webhook *models.Webhook, delivery *models.WebhookDelivery, now time.Time) (bool, error) {
+	kodeRespons, sendErr := ws.send(webhook, delivery)
+
+	delivery.Percobaan++
+	delivery.KodeRespons = kodeRespons
+
+	if sendErr == nil {
+		delivery.Status = "delivered"
+		delivery.TerkirimPada = &now
+		delivery.PesanGagal = ""
+
+		webhook.GagalBeruntun = 0
+	} else {
+		delivery.PesanGagal = sendErr.Error()
+		if delivery.Percobaan >= config.GetWebhookMaxAttempts() {
+			delivery.Status = "failed"
+		} else {
+			delivery.BerikutnyaPada = now.Add(webhookRetryDelay(delivery.Percobaan))
+		}
+
+		webhook.GagalBeruntun++
+		if webhook.GagalBeruntun >= config.GetWebhookMaxFailures() {
+			webhook.Aktif = false
+			webhook.DinonaktifkanPada = &now
+
+			log.Printf("Webhook %d disabled after %d failed deliveries: %s", webhook.ID, webhook.GagalBeruntun, sendErr.Error())
+		}
+	}
+
+	err := ws.DB.Model(delivery).Select("status", "percobaan", "berikutnya_pada", "kode_respons", "pesan_gagal", "terkirim_pada").Updates(delivery).Error
+	if err != nil {
+		return false, errors.New("error updating webhook delivery: " + err.Error())
+	}
+
+	err = ws.DB.Model(webhook).Select("aktif", "gagal_beruntun", "dinonaktifkan_pada").Updates(webhook).Error
+	if err != nil {
+		return false, errors.New("error updating webhook: " + err.Error())
+	}
+
+	return sendErr == nil, nil
+}
+
+// send posts the payload, signed in the X-Signature header: "sha256=" followed by the hex
+// HMAC-SHA256 of the X-Timestamp value, a dot and the body, with the webhook secret. Receivers can
+// reject old timestamps to ignore replayed requests.
+func (ws *WebhookService) send(webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event", delivery.Event)
+	req.Header.Set("X-Event-ID", delivery.EventID)
+	req.Header.Set("X-Delivery-ID", strconv.FormatUint(uint64(delivery.ID), 10))
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("X-Signature", "sha256="+utils.SignPayload([]byte(webhook.Secret), webhookSignedPayload(timestamp, delivery.Payload)))
+
+	resp, err := ws.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func webhookSignedPayload(timestamp string, payload string) []byte {
+	return []byte(timestamp + "." + payload)
+}
+
+// DeliverWebhooks is the scheduled job that sends pending webhook deliveries.
+func (ws *WebhookService) DeliverWebhooks() {
+	delivered, err := ws.DeliverDueWebhooks(time.Now())
+	if err != nil {
+		log.Println("Error delivering webhooks: " + err.Error())
+	} else if delivered > 0 {
+		log.Printf("Delivered %d webhook events", delivered)
+	}
+}
+
+func webhookRetryDelay(percobaan int) time.Duration {
+	delay := config.GetWebhookRetryBackoff()
+	for i := 1; i < percobaan && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxWebhookRetryDelay)
+}
+
+func joinWebhookEvents(events []string) string {
+	var unique []string
+	for _, event := range events {
+		if !slices.Contains(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+
+	return strings.Join(unique, ",")
+}
+
+// publishWebhookEvent queues the event for every active webhook subscribed to it. It runs in the
+// caller's transaction, so the event is only sent when the change itself is committed.
+func publishWebhookEvent(tx *gorm.DB, event string, data any) error {
+	var webhooks []models.Webhook
+	if err := tx.Where("aktif = ?", true).Find(&webhooks).Error; err != nil {
+		return errors.New("error loading webhooks: " + err.Error())
+	}
+
+	now := time.Now()
+	eventID := uuid.New().String()
+	var payload []byte
+
+	for _, webhook := range webhooks {
+		if !slices.Contains(strings.Split(webhook.Events, ","), event) {
+			continue
+		}
+
+		if payload == nil {
+			body, err := json.Marshal(models.WebhookEvent{
+				ID:         eventID,
+				Event:      event,
+				DibuatPada: now.Format(time.RFC3339),
+				Data:       data,
+			})
+			if err != nil {
+				return errors.New("error encoding webhook event: " + err.Error())
+			}
+			payload = body
+		}
+
+		delivery := models.WebhookDelivery{
+			WebhookID:      webhook.ID,
+			EventID:        eventID,
+			Event:          event,
+			Payload:        string(payload),
+			Status:         "pending",
+			BerikutnyaPada: now,
+		}
+
+		if err := tx.Create(&delivery).Error; err != nil {
+			return errors.New("error queueing webhook event: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func publishOrderEvent(tx *gorm.DB, event string, order *models.Order, statusSebelum string) error {
+	return publishWebhookEvent(tx, event, models.WebhookOrderData{
+		ID:            order.ID,
+		NomorOrder:    order.NomorOrder,
+		UserID:        order.UserID,
+		Status:        order.Status,
+		StatusSebelum: statusSebelum,
+		MataUang:      order.MataUang,
+		TotalHarga:    order.TotalHarga,
+	})
+}
+
+func publishStockChanged(tx *gorm.DB, inventory *models.Inventory, jumlahSebelum int) error {
+	if inventory.Jumlah == jumlahSebelum {
+		return nil
+	}
+
+	var product models.Product
+	if err := tx.Unscoped().Select("id", "sku").First(&product, inventory.ProductID).Error; err != nil {
+		return errors.New("error loading product: " + err.Error())
+	}
+
+	return publishWebhookEvent(tx, models.WebhookEventStockChanged, models.WebhookStockData{
+		InventoryID:   inventory.ID,
+		ProductID:     inventory.ProductID,
+		SKU:           product.SKU,
+		Lokasi:        inventory.Lokasi,
+		JumlahSebelum: jumlahSebelum,
+		Jumlah:        inventory.Jumlah,
+	})
+}
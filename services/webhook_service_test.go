@@ -0,0 +1,62 @@
+package services
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	t.Setenv("WEBHOOK_RETRY_BACKOFF", "1m")
+
+	tests := []struct {
+		percobaan int
+		want      time.Duration
+	}{
+		{percobaan: 0, want: time.Minute},
+		{percobaan: 1, want: time.Minute},
+		{percobaan: 2, want: 2 * time.Minute},
+		{percobaan: 4, want: 8 * time.Minute},
+		{percobaan: 11, want: 1024 * time.Minute},
+		{percobaan: 12, want: 24 * time.Hour},
+		{percobaan: 100, want: 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := webhookRetryDelay(tt.percobaan); got != tt.want {
+			t.Errorf("webhookRetryDelay(%d) = %v, want %v", tt.percobaan, got, tt.want)
+		}
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://example.com/hooks", wantErr: false},
+		{url: "http://203.0.113.10:8080/hooks", wantErr: false},
+		{url: "ftp://example.com/hooks", wantErr: true},
+		{url: "example.com/hooks", wantErr: true},
+		{url: "http://127.0.0.1/hooks", wantErr: true},
+		{url: "http://10.0.0.5/hooks", wantErr: true},
+		{url: "http://192.168.1.1/hooks", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "http://[::1]/hooks", wantErr: true},
+		{url: "http://0.0.0.0/hooks", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := validateWebhookURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("validateWebhookURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWebhookTargetAllowedWithPrivateTargets(t *testing.T) {
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_TARGETS", "true")
+
+	if !webhookTargetAllowed(net.ParseIP("127.0.0.1")) {
+		t.Error("webhookTargetAllowed(127.0.0.1) = false, want true when private targets are allowed")
+	}
+}
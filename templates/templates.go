@@ -0,0 +1,15 @@
+package templates
+
+import (
+	"embed"
+	"text/template"
+)
+
+//go:embed email
+var files embed.FS
+
+// Email parses the email template of a notification type in a language. Every template defines
+// a "subject" and a "body"; common.tmpl holds the definitions shared by the templates of a language.
+func Email(bahasa string, jenis string) (*template.Template, error) {
+	return template.ParseFS(files, "email/"+bahasa+"/common.tmpl", "email/"+bahasa+"/"+jenis+".tmpl")
+}
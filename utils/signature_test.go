@@ -0,0 +1,42 @@
+package utils
+
+import "testing"
+
+func TestSignPayload(t *testing.T) {
+	got := SignPayload([]byte("key"), []byte("The quick brown fox jumps over the lazy dog"))
+	want := "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+
+	if got != want {
+		t.Errorf("SignPayload() = %s, want %s", got, want)
+	}
+}
+
+func TestVerifyPayloadSignature(t *testing.T) {
+	secret := []byte("secret")
+	payload := []byte(`{"id":1}`)
+	signature := SignPayload(secret, payload)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid", secret: secret, payload: payload, signature: signature, want: true},
+		{name: "valid with prefix", secret: secret, payload: payload, signature: "sha256=" + signature, want: true},
+		{name: "other payload", secret: secret, payload: []byte(`{"id":2}`), signature: signature, want: false},
+		{name: "other secret", secret: []byte("other"), payload: payload, signature: signature, want: false},
+		{name: "empty secret", secret: nil, payload: payload, signature: SignPayload(nil, payload), want: false},
+		{name: "empty signature", secret: secret, payload: payload, signature: "", want: false},
+		{name: "not hex", secret: secret, payload: payload, signature: "zz", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPayloadSignature(tt.secret, tt.payload, tt.signature); got != tt.want {
+				t.Errorf("VerifyPayloadSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package utils
+
+import "testing"
+
+func TestXLSXColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{index: 0, want: "A"},
+		{index: 25, want: "Z"},
+		{index: 26, want: "AA"},
+		{index: 51, want: "AZ"},
+		{index: 52, want: "BA"},
+		{index: 701, want: "ZZ"},
+		{index: 702, want: "AAA"},
+	}
+
+	for _, tt := range tests {
+		if got := XLSXColumnName(tt.index); got != tt.want {
+			t.Errorf("XLSXColumnName(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}